@@ -1 +1,196 @@
 package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/drawsim"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+	"github.com/dgunzy/hand-eval/pkg/report"
+)
+
+const usage = `usage: rankutil <command> [flags]
+
+commands:
+  simulate  report the outcomes of a 2-7 draw
+  tables    generate the 2-7 draw outcome table file
+  odds      chance that a 2-7 draw makes a target hand or better, or a
+            hand matching an expression such as "9-low or better"
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "simulate":
+		err = runSimulate(os.Args[2:])
+	case "tables":
+		err = runTables(os.Args[2:])
+	case "odds":
+		err = runOdds(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "rankutil:", err)
+		os.Exit(1)
+	}
+}
+
+func runSimulate(args []string) error {
+	fs := flag.NewFlagSet("simulate", flag.ExitOnError)
+	keep := fs.String("keep", "", "kept cards, such as \"8c 7d 6h 3s\"")
+	dead := fs.String("dead", "", "dead cards")
+	trials := fs.Int("trials", 0, "draws to simulate; 0 enumerates every draw exactly")
+	byCategory := fs.Bool("category", false, "group outcomes by category only")
+	ascii := fs.Bool("ascii", false, "draw bars with ASCII characters")
+	width := fs.Int("width", 40, "length of a full bar")
+	where := fs.String("where", "", "also report the chance of hands matching an expression, such as \"≤ 8-6\"")
+	fs.Parse(args)
+
+	var match deucelowsingle.Predicate
+	if *where != "" {
+		var err error
+		if match, err = deucelowsingle.Compile(*where); err != nil {
+			return err
+		}
+	}
+
+	kept, err := deck.ParseCards(*keep)
+	if err != nil {
+		return err
+	}
+	deadCards, err := deck.ParseCards(*dead)
+	if err != nil {
+		return err
+	}
+	ht := deucelowsingle.NewHashTable()
+	ds, err := drawsim.NewValidatedSimulator(kept, deadCards, ht.Rules().HandSize-len(kept), drawsim.WithEvaluator(ht))
+	if err != nil {
+		return err
+	}
+	var dist *drawsim.Distribution
+	if *trials > 0 {
+		dist, err = ds.Distribution(*trials)
+	} else {
+		dist, err = ds.Enumerate()
+	}
+	if err != nil {
+		return err
+	}
+
+	opts := report.Options{Style: report.Unicode, Width: *width}
+	if *ascii {
+		opts.Style = report.ASCII
+	}
+	label := report.DeuceSevenLabel
+	if *byCategory {
+		label = report.DeuceSevenCategory
+	}
+	fmt.Printf("Drawing %d to %s: %d outcomes\n\n", ht.Rules().HandSize-len(kept), deck.FormatCards(kept), dist.Total())
+	if err := report.CategoryTable(os.Stdout, dist, label, opts); err != nil {
+		return err
+	}
+	fmt.Println()
+	if err := report.CumulativeCurve(os.Stdout, dist, label, opts); err != nil {
+		return err
+	}
+	if match != nil {
+		fmt.Printf("\nP(%s) = %.4f\n", *where, dist.Probability(match))
+	}
+	return nil
+}
+
+func runTables(args []string) error {
+	fs := flag.NewFlagSet("tables", flag.ExitOnError)
+	out := fs.String("o", "drawtable.bin", "output file")
+	maxDraw := fs.Int("max", 5, "largest draw to tabulate")
+	fs.Parse(args)
+
+	table, err := drawsim.GenerateDrawTable(deucelowsingle.NewHashTable(), *maxDraw)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	n, err := table.WriteTo(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s (%d bytes)\n", *out, n)
+	return nil
+}
+
+func runOdds(args []string) error {
+	fs := flag.NewFlagSet("odds", flag.ExitOnError)
+	tablePath := fs.String("table", "", "draw table file; without one the draw is enumerated")
+	keep := fs.String("keep", "", "kept cards, such as \"8c 7d 6h 3s\"")
+	dead := fs.String("dead", "", "dead cards")
+	target := fs.String("target", "", "five-card target hand, such as \"9s 8h 7h 6c 4d\"")
+	where := fs.String("where", "", "expression the final hand must match, such as \"9-low or better\"")
+	fs.Parse(args)
+
+	kept, err := deck.ParseCards(*keep)
+	if err != nil {
+		return err
+	}
+	deadCards, err := deck.ParseCards(*dead)
+	if err != nil {
+		return err
+	}
+	ht := deucelowsingle.NewHashTable()
+	var match deucelowsingle.Predicate
+	if *where != "" {
+		if match, err = deucelowsingle.Compile(*where); err != nil {
+			return err
+		}
+	} else {
+		targetCards, err := deck.ParseCards(*target)
+		if err != nil {
+			return err
+		}
+		if len(targetCards) != ht.Rules().HandSize {
+			return fmt.Errorf("target must be %d cards, or give an expression with -where", ht.Rules().HandSize)
+		}
+		v := ht.Value(targetCards)
+		match = func(x deucelowsingle.HandValue) bool { return !ht.Rules().Better(v, x) }
+	}
+
+	ds, err := drawsim.NewValidatedSimulator(kept, deadCards, ht.Rules().HandSize-len(kept), drawsim.WithEvaluator(ht))
+	if err != nil {
+		return err
+	}
+	var dist *drawsim.Distribution
+	if *tablePath != "" {
+		f, err := os.Open(*tablePath)
+		if err != nil {
+			return err
+		}
+		table, err := drawsim.ReadDrawTable(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		dist, err = ds.Lookup(table)
+		if err != nil {
+			return err
+		}
+	} else if dist, err = ds.Enumerate(); err != nil {
+		return err
+	}
+
+	p := dist.Probability(match)
+	fmt.Printf("%.4f (%.0f of %d draws)\n", p, p*float64(dist.Total()), dist.Total())
+	return nil
+}
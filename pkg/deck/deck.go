@@ -0,0 +1,119 @@
+package deck
+
+import (
+	"math/bits"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+const (
+	// StandardSize is the number of cards in a standard deck, which use
+	// card codes 0 through 51
+	StandardSize = 52
+
+	// Joker and SecondJoker use the card codes just past the standard deck
+	Joker       = card.Card(StandardSize)
+	SecondJoker = card.Card(StandardSize + 1)
+
+	standardMask = uint64(1)<<StandardSize - 1
+)
+
+// IsJoker reports whether c is one of the two joker codes
+func IsJoker(c card.Card) bool {
+	return c == Joker || c == SecondJoker
+}
+
+// Deck is an immutable set of cards. The zero value is an empty deck.
+type Deck struct {
+	mask uint64
+}
+
+// New returns a deck holding the given cards. Codes outside the standard
+// deck and jokers are ignored.
+func New(cards ...card.Card) Deck {
+	var d Deck
+	for _, c := range cards {
+		if valid(c) {
+			d.mask |= 1 << uint(c)
+		}
+	}
+	return d
+}
+
+// Standard returns the 52-card deck
+func Standard() Deck {
+	return Deck{mask: standardMask}
+}
+
+// ShortDeck returns the 36-card deck from six through ace
+func ShortDeck() Deck {
+	return Standard().WithoutRanks(card.Two, card.Three, card.Four, card.Five)
+}
+
+// Piquet returns the 32-card deck from seven through ace
+func Piquet() Deck {
+	return ShortDeck().WithoutRanks(card.Six)
+}
+
+// WithJokers returns the deck with one or two jokers added
+func (d Deck) WithJokers(n int) Deck {
+	if n >= 1 {
+		d.mask |= 1 << uint(Joker)
+	}
+	if n >= 2 {
+		d.mask |= 1 << uint(SecondJoker)
+	}
+	return d
+}
+
+// Without returns the deck with the given cards removed
+func (d Deck) Without(cards ...card.Card) Deck {
+	for _, c := range cards {
+		if valid(c) {
+			d.mask &^= 1 << uint(c)
+		}
+	}
+	return d
+}
+
+// WithoutRanks returns the deck with every card of the given ranks removed
+func (d Deck) WithoutRanks(ranks ...card.Rank) Deck {
+	for i := 0; i < StandardSize; i++ {
+		c := card.Card(i)
+		for _, r := range ranks {
+			if c.Rank() == r {
+				d.mask &^= 1 << uint(i)
+			}
+		}
+	}
+	return d
+}
+
+// Contains reports whether c is in the deck
+func (d Deck) Contains(c card.Card) bool {
+	return valid(c) && d.mask&(1<<uint(c)) != 0
+}
+
+// Len returns the number of cards in the deck
+func (d Deck) Len() int {
+	return bits.OnesCount64(d.mask)
+}
+
+// Cards returns the deck's cards in ascending card code order
+func (d Deck) Cards() []card.Card {
+	cards := make([]card.Card, 0, d.Len())
+	for m := d.mask; m != 0; m &= m - 1 {
+		cards = append(cards, card.Card(bits.TrailingZeros64(m)))
+	}
+	return cards
+}
+
+// Jokers returns how many jokers the deck holds
+func (d Deck) Jokers() int {
+	return bits.OnesCount64(d.mask &^ standardMask)
+}
+
+// valid reports whether c is a standard card or a joker
+func valid(c card.Card) bool {
+	return c <= SecondJoker
+}
@@ -0,0 +1,95 @@
+package deck
+
+import (
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+func TestDeckDefinitions(t *testing.T) {
+	tests := []struct {
+		name   string
+		deck   Deck
+		size   int
+		lowest card.Rank
+		jokers int
+	}{
+		{"Standard", Standard(), 52, card.Two, 0},
+		{"Short Deck", ShortDeck(), 36, card.Six, 0},
+		{"Piquet", Piquet(), 32, card.Seven, 0},
+		{"Single Joker", Standard().WithJokers(1), 53, card.Two, 1},
+		{"Double Joker", Standard().WithJokers(2), 54, card.Two, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.deck.Len(); got != tt.size {
+				t.Errorf("Expected %d cards, got %d", tt.size, got)
+			}
+			if got := tt.deck.Jokers(); got != tt.jokers {
+				t.Errorf("Expected %d jokers, got %d", tt.jokers, got)
+			}
+			cards := tt.deck.Cards()
+			if len(cards) != tt.size {
+				t.Fatalf("Expected Cards to return %d cards, got %d", tt.size, len(cards))
+			}
+			for _, c := range cards {
+				if IsJoker(c) {
+					continue
+				}
+				if r := c.Rank(); r != card.Ace && r < tt.lowest {
+					t.Errorf("Unexpected card %v below %v", c, tt.lowest)
+				}
+				if !tt.deck.Contains(c) {
+					t.Errorf("Contains(%v) = false for a card in the deck", c)
+				}
+			}
+		})
+	}
+}
+
+func TestCustomRemovals(t *testing.T) {
+	aceSpades := card.NewCard(card.Spades, card.Ace)
+	d := Standard().Without(aceSpades)
+	if d.Contains(aceSpades) || d.Len() != 51 {
+		t.Errorf("Expected the ace of spades removed, got %d cards", d.Len())
+	}
+
+	custom := New(aceSpades, card.NewCard(card.Hearts, card.King), card.Card(200))
+	if custom.Len() != 2 {
+		t.Errorf("Expected invalid codes to be ignored, got %d cards", custom.Len())
+	}
+	if Standard().WithJokers(2).Without(Joker).Jokers() != 1 {
+		t.Error("Expected one joker left after removing the first")
+	}
+}
+
+func TestNotation(t *testing.T) {
+	for _, c := range Standard().WithJokers(2).Cards() {
+		got, err := ParseCard(FormatCard(c))
+		if err != nil || got != c {
+			t.Errorf("Expected %s to round-trip to %d, got %d (%v)", FormatCard(c), int(c), int(got), err)
+		}
+	}
+
+	cards, err := ParseCards("7s, 10h td Jk x2")
+	if err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+	want := []card.Card{
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Hearts, card.Ten),
+		card.NewCard(card.Diamonds, card.Ten),
+		Joker,
+		SecondJoker,
+	}
+	if FormatCards(cards) != FormatCards(want) {
+		t.Errorf("Expected %s, got %s", FormatCards(want), FormatCards(cards))
+	}
+
+	for _, bad := range []string{"", "7", "1s", "7x", "7ss"} {
+		if _, err := ParseCard(bad); err == nil {
+			t.Errorf("Expected an error parsing %q", bad)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package deck
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+const (
+	rankChars = "A23456789TJQK"
+	suitChars = "shdc"
+)
+
+var notationSuits = [4]card.Suit{card.Spades, card.Hearts, card.Diamonds, card.Clubs}
+
+// ParseCard reads a card written as a rank and a suit, such as "7s", "Td" or
+// "10h", case-insensitively. "Jk" and "X" are read as the joker, and "Jk2"
+// and "X2" as the second joker.
+func ParseCard(s string) (card.Card, error) {
+	switch strings.ToLower(s) {
+	case "jk", "x":
+		return Joker, nil
+	case "jk2", "x2":
+		return SecondJoker, nil
+	}
+	if len(s) == 3 && s[:2] == "10" {
+		s = "T" + s[2:]
+	}
+	if len(s) != 2 {
+		return 0, fmt.Errorf("deck: cannot parse card %q", s)
+	}
+	rank := strings.IndexByte(rankChars, strings.ToUpper(s[:1])[0])
+	suit := strings.IndexByte(suitChars, strings.ToLower(s[1:])[0])
+	if rank < 0 || suit < 0 {
+		return 0, fmt.Errorf("deck: cannot parse card %q", s)
+	}
+	return card.NewCard(notationSuits[suit], card.Rank(rank)), nil
+}
+
+// ParseCards reads cards separated by spaces or commas, such as "7s 5h 4d"
+func ParseCards(s string) ([]card.Card, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' })
+	cards := make([]card.Card, 0, len(fields))
+	for _, f := range fields {
+		c, err := ParseCard(f)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, nil
+}
+
+// FormatCard writes a card in the notation read by ParseCard
+func FormatCard(c card.Card) string {
+	switch c {
+	case Joker:
+		return "Jk"
+	case SecondJoker:
+		return "Jk2"
+	}
+	for i, s := range notationSuits {
+		if c.Suit() == s {
+			return string(rankChars[c.Rank()]) + string(suitChars[i])
+		}
+	}
+	return fmt.Sprintf("Card(%d)", int(c))
+}
+
+// FormatCards writes cards separated by spaces
+func FormatCards(cards []card.Card) string {
+	parts := make([]string, len(cards))
+	for i, c := range cards {
+		parts[i] = FormatCard(c)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,199 @@
+package drawsim
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+// Opponent is the hand a break decision is played against. Kept holds the
+// opponent's cards that are known to stay in the hand, usually none. The rest
+// of the hand is dealt at random before the draw, and the opponent then
+// throws DrawCount of those cards by the discard strategy and draws as many
+// from the same stub as the hero.
+type Opponent struct {
+	Kept      []card.Card
+	DrawCount int
+}
+
+// BreakOption is one way of playing a made hand: standing pat, or
+// discarding some cards to draw to a better hand
+type BreakOption struct {
+	Kept      []card.Card
+	Discards  []card.Card
+	DrawCount int
+	// Equity is the share of the pot won at showdown, counting ties as half
+	Equity float64
+	// Delta is Equity less the equity of standing pat
+	Delta float64
+}
+
+// BreakAnalysis compares standing pat with every way of breaking a hand
+type BreakAnalysis struct {
+	Pat BreakOption
+	// Breaks are ordered by equity, best first
+	Breaks []BreakOption
+}
+
+// Best returns the option with the highest equity, which is Pat if no break
+// does better
+func (a *BreakAnalysis) Best() BreakOption {
+	if len(a.Breaks) > 0 && a.Breaks[0].Equity > a.Pat.Equity {
+		return a.Breaks[0]
+	}
+	return a.Pat
+}
+
+// AnalyzeBreaks plays hand pat and with every discard of one to maxDiscard
+// cards against opp, running trials showdowns for each option. Dead cards,
+// such as known discards of other players, and the cards each option
+// discards are removed from the deck, as are the opponent's known cards.
+// Equities are showdown equities and do not account for betting, so the
+// value of a snow lies in the fold equity it earns beyond what is reported.
+// An opponent who draws needs a discard strategy, set with
+// WithDiscardStrategy for evaluators other than the 2-7 HashTable.
+func AnalyzeBreaks(hand, dead []card.Card, opp Opponent, maxDiscard, trials int, opts ...Option) (*BreakAnalysis, error) {
+	if trials < 0 {
+		return nil, &InvalidTrialsError{Trials: trials}
+	}
+
+	// Validate the hand with a pat simulator, which also fixes the evaluator
+	pat := NewSimulator(hand, append(append([]card.Card(nil), dead...), opp.Kept...), 0, opts...)
+	if err := pat.Validate(); err != nil {
+		return nil, err
+	}
+	handSize := pat.handEval.Rules().HandSize
+	if opp.DrawCount < 0 || len(opp.Kept)+opp.DrawCount > handSize {
+		return nil, &InvalidDrawCountError{Kept: len(opp.Kept), DrawCount: opp.DrawCount}
+	}
+
+	var discard DiscardStrategy
+	if opp.DrawCount > 0 {
+		var err error
+		if discard, err = pat.discardStrategy(); err != nil {
+			return nil, err
+		}
+	}
+
+	analysis := &BreakAnalysis{}
+	var err error
+	if analysis.Pat, err = pat.breakEquity(hand, nil, opp, discard, trials); err != nil {
+		return nil, err
+	}
+
+	for _, discards := range discardSets(hand, maxDiscard) {
+		kept := make([]card.Card, 0, len(hand)-len(discards))
+		for _, c := range hand {
+			if !containsCard(discards, c) {
+				kept = append(kept, c)
+			}
+		}
+		removed := append(append(append([]card.Card(nil), dead...), opp.Kept...), discards...)
+		ds := NewSimulator(kept, removed, len(discards), WithEvaluator(pat.handEval), withDeckOf(pat))
+		option, err := ds.breakEquity(kept, discards, opp, discard, trials)
+		if err != nil {
+			return nil, err
+		}
+		option.Delta = option.Equity - analysis.Pat.Equity
+		analysis.Breaks = append(analysis.Breaks, option)
+	}
+
+	sort.SliceStable(analysis.Breaks, func(i, j int) bool {
+		return analysis.Breaks[i].Equity > analysis.Breaks[j].Equity
+	})
+	return analysis, nil
+}
+
+// withDeckOf carries a simulator's configured deck over to another
+func withDeckOf(ds *DrawSimulator) Option {
+	return func(other *DrawSimulator) {
+		other.deck = ds.deck
+	}
+}
+
+// breakEquity plays the simulator's draw against the opponent's, each drawing
+// from the same stub so that both sides' card removal is respected
+func (ds *DrawSimulator) breakEquity(kept, discards []card.Card, opp Opponent, discard DiscardStrategy, trials int) (BreakOption, error) {
+	option := BreakOption{Kept: kept, Discards: discards, DrawCount: ds.drawCount}
+	live, err := ds.liveCards()
+	if err != nil {
+		return option, err
+	}
+	rules := ds.handEval.Rules()
+	oppUnknown := rules.HandSize - len(opp.Kept)
+	need := ds.drawCount + oppUnknown + opp.DrawCount
+	if need > len(live) {
+		return option, &InsufficientDeckError{Needed: need, Available: len(live)}
+	}
+	if trials == 0 {
+		return option, nil
+	}
+
+	ours := make([]card.Card, rules.HandSize)
+	theirs := make([]card.Card, rules.HandSize)
+	predraw := make([]card.Card, oppUnknown)
+	copy(ours, kept)
+	copy(theirs, opp.Kept)
+	var won float64
+	for t := 0; t < trials; t++ {
+		// Partial Fisher-Yates: only the cards dealt need shuffling
+		for i := 0; i < need; i++ {
+			j := i + rand.Intn(len(live)-i)
+			live[i], live[j] = live[j], live[i]
+		}
+		copy(ours[len(kept):], live[:ds.drawCount])
+		next := ds.drawCount + oppUnknown
+		dealt := theirs[len(opp.Kept):]
+		copy(dealt, live[ds.drawCount:next])
+		if opp.DrawCount > 0 {
+			copy(predraw, dealt)
+			thrown := discard(predraw, opp.DrawCount)
+			for i, c := range dealt {
+				if next < need && containsCard(thrown, c) {
+					dealt[i] = live[next]
+					next++
+				}
+			}
+		}
+
+		a, b := ds.handEval.Value(ours), ds.handEval.Value(theirs)
+		switch {
+		case rules.Better(a, b):
+			won++
+		case a == b:
+			won += 0.5
+		}
+	}
+	option.Equity = won / float64(trials)
+	return option, nil
+}
+
+// discardSets returns every subset of hand with one to max cards, smaller
+// subsets first
+func discardSets(hand []card.Card, max int) [][]card.Card {
+	var sets [][]card.Card
+	for size := 1; size <= max && size <= len(hand); size++ {
+		var choose func(start int, set []card.Card)
+		choose = func(start int, set []card.Card) {
+			if len(set) == size {
+				sets = append(sets, append([]card.Card(nil), set...))
+				return
+			}
+			for i := start; i < len(hand); i++ {
+				choose(i+1, append(set, hand[i]))
+			}
+		}
+		choose(0, nil)
+	}
+	return sets
+}
+
+func containsCard(cards []card.Card, c card.Card) bool {
+	for _, x := range cards {
+		if x == c {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package drawsim
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+func TestAnalyzeBreaks(t *testing.T) {
+	opp := Opponent{
+		Kept: []card.Card{
+			card.NewCard(card.Clubs, card.Eight),
+			card.NewCard(card.Clubs, card.Seven),
+			card.NewCard(card.Diamonds, card.Four),
+			card.NewCard(card.Diamonds, card.Three),
+		},
+		DrawCount: 1,
+	}
+
+	// The nuts never gains by breaking
+	nuts := []card.Card{
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Hearts, card.Four),
+		card.NewCard(card.Spades, card.Three),
+		card.NewCard(card.Hearts, card.Two),
+	}
+	analysis, err := AnalyzeBreaks(nuts, nil, opp, 2, 2000)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(analysis.Breaks) != 15 {
+		t.Errorf("Expected 15 one- and two-card breaks, got %d", len(analysis.Breaks))
+	}
+	if analysis.Pat.Equity != 1 {
+		t.Errorf("Expected the nuts to win every showdown, got %.3f", analysis.Pat.Equity)
+	}
+	for _, b := range analysis.Breaks {
+		if b.Delta > 0 {
+			t.Errorf("Expected no break of the nuts to gain, discarding %v gained %.3f", b.Discards, b.Delta)
+		}
+		if math.Abs(b.Delta-(b.Equity-analysis.Pat.Equity)) > 1e-12 {
+			t.Errorf("Expected delta to be equity less pat equity, got %.3f", b.Delta)
+		}
+	}
+
+	// A made 6-5-4-3-2 straight should break for a one-card draw
+	straight := []card.Card{
+		card.NewCard(card.Spades, card.Six),
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Hearts, card.Four),
+		card.NewCard(card.Spades, card.Three),
+		card.NewCard(card.Hearts, card.Two),
+	}
+	analysis, err = AnalyzeBreaks(straight, nil, opp, 1, 4000)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	best := analysis.Best()
+	if len(best.Discards) != 1 {
+		t.Errorf("Expected a one-card break to be best, got discards %v", best.Discards)
+	}
+	if best.Delta < 0.2 {
+		t.Errorf("Expected breaking the straight to gain substantial equity, got %.3f", best.Delta)
+	}
+}
+
+func TestAnalyzeBreaksValidation(t *testing.T) {
+	hand := []card.Card{
+		card.NewCard(card.Spades, card.Nine),
+		card.NewCard(card.Hearts, card.Seven),
+		card.NewCard(card.Hearts, card.Six),
+		card.NewCard(card.Spades, card.Four),
+		card.NewCard(card.Hearts, card.Two),
+	}
+
+	_, err := AnalyzeBreaks(hand, nil, Opponent{Kept: hand[:1], DrawCount: 4}, 1, 10)
+	var dupErr *DuplicateCardError
+	if !errors.As(err, &dupErr) {
+		t.Errorf("Expected DuplicateCardError, got %v", err)
+	}
+
+	_, err = AnalyzeBreaks(hand, nil, Opponent{DrawCount: 6}, 1, 10)
+	var drawErr *InvalidDrawCountError
+	if !errors.As(err, &drawErr) {
+		t.Errorf("Expected InvalidDrawCountError, got %v", err)
+	}
+
+	_, err = AnalyzeBreaks(hand[:4], nil, Opponent{DrawCount: 1}, 1, 10)
+	if !errors.As(err, &drawErr) {
+		t.Errorf("Expected InvalidDrawCountError for a four-card hand, got %v", err)
+	}
+}
+
+func TestAnalyzeBreaksOpponentDraw(t *testing.T) {
+	hand := []card.Card{
+		card.NewCard(card.Spades, card.Jack),
+		card.NewCard(card.Hearts, card.Ten),
+		card.NewCard(card.Hearts, card.Eight),
+		card.NewCard(card.Spades, card.Five),
+		card.NewCard(card.Clubs, card.Three),
+	}
+
+	// With none of the opponent's cards known, a pat opponent holds a random
+	// hand while one drawing three keeps their two best cards first
+	pat, err := AnalyzeBreaks(hand, nil, Opponent{}, 0, 4000)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	drawing, err := AnalyzeBreaks(hand, nil, Opponent{DrawCount: 3}, 0, 4000)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if drawing.Pat.Equity > pat.Pat.Equity-0.04 {
+		t.Errorf("Expected a drawing opponent to cut pat equity from %.3f, got %.3f", pat.Pat.Equity, drawing.Pat.Equity)
+	}
+}
@@ -0,0 +1,244 @@
+package drawsim
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dgunzy/hand-eval/pkg/suitiso"
+)
+
+// Store persists distributions cached by a Cache, so that they survive
+// restarts. Load reports false for a key it does not hold.
+type Store interface {
+	Load(key string) (*Distribution, bool, error)
+	Save(key string, d *Distribution) error
+}
+
+// CacheStats counts how a cache's lookups were answered
+type CacheStats struct {
+	Hits      int // from memory
+	StoreHits int // from the store
+	Misses    int // computed
+}
+
+// Cache is a least recently used cache of draw distributions, safe for
+// concurrent use. Entries are keyed by the evaluator, deck, draw count and
+// trial budget, and by the kept and dead cards up to a relabelling of suits,
+// so suit-permuted queries share an entry. Evaluators of the same type must
+// therefore score hands alike and treat the suits alike.
+type Cache struct {
+	mu       sync.Mutex
+	capacity int
+	// order holds entries from most to least recently used
+	order   *list.List
+	entries map[string]*list.Element
+	store   Store
+	stats   CacheStats
+}
+
+type cacheEntry struct {
+	key  string
+	dist *Distribution
+}
+
+// NewCache creates a cache holding up to capacity distributions in memory,
+// or none if capacity is zero. store may be nil; otherwise it is read on a
+// miss and written with every distribution computed.
+func NewCache(capacity int, store Store) *Cache {
+	return &Cache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		store:    store,
+	}
+}
+
+// WithCache answers Distribution and Enumerate from c where it can
+func WithCache(c *Cache) Option {
+	return func(ds *DrawSimulator) {
+		ds.cache = c
+	}
+}
+
+// Len returns the number of distributions held in memory
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Stats returns the cache's lookup counts
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// get returns the distribution for key, computing and storing it on a miss.
+// The computation runs without the lock, so concurrent misses on one key may
+// each compute it.
+func (c *Cache) get(key string, compute func() (*Distribution, error)) (*Distribution, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		c.stats.Hits++
+		c.mu.Unlock()
+		return e.Value.(*cacheEntry).dist, nil
+	}
+	c.mu.Unlock()
+
+	if c.store != nil {
+		d, ok, err := c.store.Load(key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			c.add(key, d, func(s *CacheStats) { s.StoreHits++ })
+			return d, nil
+		}
+	}
+
+	d, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	if c.store != nil {
+		if err := c.store.Save(key, d); err != nil {
+			return nil, err
+		}
+	}
+	c.add(key, d, func(s *CacheStats) { s.Misses++ })
+	return d, nil
+}
+
+// add inserts a distribution, evicting the least recently used beyond the
+// capacity, and updates the stats
+func (c *Cache) add(key string, d *Distribution, count func(*CacheStats)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	count(&c.stats)
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, dist: d})
+	for c.order.Len() > c.capacity && c.order.Len() > 0 {
+		last := c.order.Back()
+		c.order.Remove(last)
+		delete(c.entries, last.Value.(*cacheEntry).key)
+	}
+}
+
+// exactTrials stands for the trial budget of exact enumeration in cache keys
+const exactTrials = -1
+
+// cacheKey identifies the simulator's draw for a trial budget
+func (ds *DrawSimulator) cacheKey(trials int) string {
+	canonical := suitiso.Canonicalize(ds.keptCards, ds.deadCards, ds.cards().Cards())
+	return fmt.Sprintf("%T %v %d %d ", ds.handEval, ds.handEval.Rules(), ds.drawCount, trials) + canonical.Key()
+}
+
+// cached answers compute from the simulator's cache, if it has one. Inputs
+// are validated first so that only valid draws are cached.
+func (ds *DrawSimulator) cached(trials int, compute func() (*Distribution, error)) (*Distribution, error) {
+	if ds.cache == nil {
+		return compute()
+	}
+	if err := ds.Validate(); err != nil {
+		return nil, err
+	}
+	return ds.cache.get(ds.cacheKey(trials), compute)
+}
+
+// DiskStore is a Store keeping each distribution in its own file in a
+// directory
+type DiskStore struct {
+	dir string
+}
+
+const (
+	cacheFileMagic   = "DRWC"
+	cacheFileVersion = 1
+)
+
+// OpenDiskStore creates dir if needed and returns a store using it
+func OpenDiskStore(dir string) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DiskStore{dir: dir}, nil
+}
+
+// path names a key's file by its hash
+func (s *DiskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".dist")
+}
+
+// Load reads the distribution stored for key. A file that cannot be decoded
+// or was written for another key is treated as missing, and is replaced by
+// the next Save.
+func (s *DiskStore) Load(key string) (*Distribution, bool, error) {
+	f, err := os.Open(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	defer f.Close()
+
+	d := newDecoder(f)
+	magic := make([]byte, len(cacheFileMagic))
+	if _, err := io.ReadFull(d.r, magic); err != nil || string(magic) != cacheFileMagic {
+		return nil, false, nil
+	}
+	if d.uvarint() != cacheFileVersion {
+		return nil, false, nil
+	}
+	if n := d.uvarint(); d.err != nil || n != uint64(len(key)) {
+		return nil, false, nil
+	}
+	stored := make([]byte, len(key))
+	if _, err := io.ReadFull(d.r, stored); err != nil || string(stored) != key {
+		return nil, false, nil
+	}
+	dist := d.distribution(d.rules())
+	if d.err != nil {
+		return nil, false, nil
+	}
+	return dist, true, nil
+}
+
+// Save writes the distribution for key, replacing the file atomically so
+// that concurrent readers never see a partial entry
+func (s *DiskStore) Save(key string, dist *Distribution) error {
+	f, err := os.CreateTemp(s.dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	e := newEncoder(f)
+	e.w.WriteString(cacheFileMagic)
+	e.uvarint(cacheFileVersion)
+	e.uvarint(uint64(len(key)))
+	e.w.WriteString(key)
+	e.rules(dist.rules)
+	e.distribution(dist)
+	err = e.w.Flush()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path(key))
+}
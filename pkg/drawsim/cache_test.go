@@ -0,0 +1,79 @@
+package drawsim
+
+import (
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+func TestCache(t *testing.T) {
+	ht := deucelowsingle.NewHashTable()
+	store, err := OpenDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Unexpected store error: %v", err)
+	}
+	cache := NewCache(2, store)
+
+	kept := []card.Card{
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Hearts, card.Three),
+	}
+	dead := []card.Card{card.NewCard(card.Clubs, card.Two)}
+	first, err := NewSimulator(kept, dead, 2, WithEvaluator(ht), WithCache(cache)).Enumerate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The same draw with spades and diamonds swapped, and hearts and clubs,
+	// is answered from memory
+	permuted := []card.Card{
+		card.NewCard(card.Diamonds, card.Seven),
+		card.NewCard(card.Clubs, card.Five),
+		card.NewCard(card.Clubs, card.Three),
+	}
+	permutedDead := []card.Card{card.NewCard(card.Hearts, card.Two)}
+	second, err := NewSimulator(permuted, permutedDead, 2, WithEvaluator(ht), WithCache(cache)).Enumerate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if second != first {
+		t.Errorf("Expected a suit-permuted draw to share the cached distribution")
+	}
+	if stats := cache.Stats(); stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("Expected one hit and one miss, got %+v", stats)
+	}
+
+	// A sampled distribution is cached apart from the exact one, and filling
+	// the cache evicts the least recently used entry
+	ds := NewSimulator(kept, dead, 2, WithEvaluator(ht), WithCache(cache))
+	if _, err := ds.Distribution(100); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := NewSimulator(kept[:2], nil, 3, WithEvaluator(ht), WithCache(cache)).Enumerate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 cached distributions, got %d", cache.Len())
+	}
+	if stats := cache.Stats(); stats.Misses != 3 {
+		t.Errorf("Expected 3 misses, got %+v", stats)
+	}
+
+	// A new cache over the same store reloads the evicted exact distribution
+	restarted := NewCache(2, store)
+	reloaded, err := NewSimulator(kept, dead, 2, WithEvaluator(ht), WithCache(restarted)).Enumerate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats := restarted.Stats(); stats.StoreHits != 1 {
+		t.Errorf("Expected a store hit, got %+v", stats)
+	}
+	assertSameDistribution(t, kept, reloaded, first)
+
+	// Invalid draws are reported and not cached
+	if _, err := NewSimulator(kept, dead, 3, WithEvaluator(ht), WithCache(cache)).Enumerate(); err == nil {
+		t.Errorf("Expected an invalid draw count error")
+	}
+}
@@ -0,0 +1,47 @@
+package drawsim
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+)
+
+func TestSimulatorDeck(t *testing.T) {
+	kept := []card.Card{
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Hearts, card.Eight),
+	}
+	removed := card.NewCard(card.Clubs, card.Nine)
+	d := deck.Piquet().Without(removed)
+
+	sim, err := NewValidatedSimulator(kept, nil, 3, WithDeck(d))
+	if err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	results, err := sim.RunSimulation(500)
+	if err != nil {
+		t.Fatalf("Unexpected simulation error: %v", err)
+	}
+	for _, result := range results {
+		for _, c := range result.Hand {
+			if !d.Contains(c) {
+				t.Fatalf("Drew %v, which is not in the configured deck", c)
+			}
+		}
+	}
+
+	// A custom deck too small for the draw is rejected
+	tiny := deck.New(kept...).Without(kept[1])
+	if _, err := NewValidatedSimulator(kept[:1], nil, 4, WithDeck(tiny)); err == nil {
+		t.Error("Expected an error drawing from a deck with no live cards")
+	}
+
+	// Jokers score as the worst hand without a wild-card evaluator
+	_, err = NewValidatedSimulator(kept, nil, 3, WithDeck(deck.Standard().WithJokers(1)))
+	var cardErr *UnscorableCardError
+	if !errors.As(err, &cardErr) || cardErr.Card != deck.Joker {
+		t.Errorf("Expected UnscorableCardError for the joker, got %v", err)
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"sort"
 
 	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
 	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
 )
 
-// SimulationResult represents a single draw result
+// SimulationResult represents a single draw result. Percentile is the share
+// of results as good as or better than this one, so tied hands share it.
 type SimulationResult struct {
 	Hand       []card.Card
-	HandValue  deucelowsingle.HandValue
+	HandValue  handrank.HandValue
 	Percentile float64
 }
 
@@ -19,66 +22,179 @@ type DrawSimulator struct {
 	keptCards []card.Card
 	deadCards []card.Card
 	drawCount int
-	handEval  *deucelowsingle.HashTable
+	handEval  handrank.Evaluator
+	deck      *deck.Deck
+	cache     *Cache
+	discard   DiscardStrategy
 	results   []SimulationResult
 }
 
-func NewSimulator(kept []card.Card, dead []card.Card, drawCount int) *DrawSimulator {
-	return &DrawSimulator{
+// Option configures a DrawSimulator
+type Option func(*DrawSimulator)
+
+// WithEvaluator scores drawn hands with e instead of the default 2-7
+// evaluator. Results are ordered by the evaluator's rules, so high games
+// sort their best, highest values first.
+func WithEvaluator(e handrank.Evaluator) Option {
+	return func(ds *DrawSimulator) {
+		ds.handEval = e
+	}
+}
+
+// WithDeck draws from d instead of the deck of the evaluator's variant. Every
+// card of d must be one the evaluator can score, so jokers need a wild-card
+// evaluator; Validate reports any other card with an UnscorableCardError.
+// Decks built with deck.New have already dropped codes that are not cards.
+func WithDeck(d deck.Deck) Option {
+	return func(ds *DrawSimulator) {
+		ds.deck = &d
+	}
+}
+
+// NewSimulator creates a simulator without checking its inputs. Invalid
+// inputs are reported by RunSimulation.
+func NewSimulator(kept []card.Card, dead []card.Card, drawCount int, opts ...Option) *DrawSimulator {
+	ds := &DrawSimulator{
 		keptCards: kept,
 		deadCards: dead,
 		drawCount: drawCount,
-		handEval:  deucelowsingle.NewHashTable(),
 	}
+	for _, opt := range opts {
+		opt(ds)
+	}
+	if ds.handEval == nil {
+		ds.handEval = deucelowsingle.NewHashTable()
+	}
+	return ds
 }
 
-func (ds *DrawSimulator) RunSimulation(n int) []SimulationResult {
-	ds.results = make([]SimulationResult, 0, n)
+// NewValidatedSimulator creates a simulator, returning a typed error if the
+// kept and dead cards overlap, the draw does not complete a five-card hand,
+// or the remaining deck is too small to draw from
+func NewValidatedSimulator(kept []card.Card, dead []card.Card, drawCount int, opts ...Option) (*DrawSimulator, error) {
+	ds := NewSimulator(kept, dead, drawCount, opts...)
+	if err := ds.Validate(); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// Validate checks the simulator's kept cards, dead cards and draw count
+func (ds *DrawSimulator) Validate() error {
+	_, err := ds.liveCards()
+	return err
+}
+
+// cards returns the configured deck, falling back to the evaluator's deck
+func (ds *DrawSimulator) cards() deck.Deck {
+	if ds.deck != nil {
+		return *ds.deck
+	}
+	return ds.evaluatorDeck()
+}
+
+// evaluatorDeck returns the deck of the evaluator's variant, or the standard
+// 52 cards
+func (ds *DrawSimulator) evaluatorDeck() deck.Deck {
+	if dp, ok := ds.handEval.(handrank.DeckProvider); ok {
+		return dp.Deck()
+	}
+	return deck.Standard()
+}
+
+// scorableCards returns the deck, checking that the evaluator can score
+// every card of a configured one
+func (ds *DrawSimulator) scorableCards() (deck.Deck, error) {
+	d := ds.cards()
+	if ds.deck != nil {
+		scorable := ds.evaluatorDeck()
+		for _, c := range d.Cards() {
+			if !scorable.Contains(c) {
+				return d, &UnscorableCardError{Card: c}
+			}
+		}
+	}
+	return d, nil
+}
+
+// liveCards returns the deck minus kept and dead cards, validating inputs
+func (ds *DrawSimulator) liveCards() ([]card.Card, error) {
+	d, err := ds.scorableCards()
+	if err != nil {
+		return nil, err
+	}
 	usedCards := make(map[card.Card]bool)
 
 	// Mark kept and dead cards as used
-	for _, c := range ds.keptCards {
-		usedCards[c] = true
+	for _, cards := range [][]card.Card{ds.keptCards, ds.deadCards} {
+		for _, c := range cards {
+			if !d.Contains(c) {
+				return nil, &InvalidCardError{Card: c}
+			}
+			if usedCards[c] {
+				return nil, &DuplicateCardError{Card: c}
+			}
+			usedCards[c] = true
+		}
 	}
-	for _, c := range ds.deadCards {
-		usedCards[c] = true
+
+	if ds.drawCount < 0 || len(ds.keptCards)+ds.drawCount != ds.handEval.Rules().HandSize {
+		return nil, &InvalidDrawCountError{Kept: len(ds.keptCards), DrawCount: ds.drawCount}
 	}
 
 	// Create deck without used cards
-	availableCards := make([]card.Card, 0, 52-len(usedCards))
-	for i := 0; i < 52; i++ {
-		c := card.Card(i)
+	availableCards := make([]card.Card, 0, d.Len()-len(usedCards))
+	for _, c := range d.Cards() {
 		if !usedCards[c] {
 			availableCards = append(availableCards, c)
 		}
 	}
 
+	if ds.drawCount > len(availableCards) {
+		return nil, &InsufficientDeckError{Needed: ds.drawCount, Available: len(availableCards)}
+	}
+	return availableCards, nil
+}
+
+// RunSimulation draws n hands and returns them sorted best to worst. Every
+// trial produces a result; invalid inputs return an error instead.
+func (ds *DrawSimulator) RunSimulation(n int) ([]SimulationResult, error) {
+	if n < 0 {
+		return nil, &InvalidTrialsError{Trials: n}
+	}
+	availableCards, err := ds.liveCards()
+	if err != nil {
+		return nil, err
+	}
+
 	// Run simulations
+	ds.results = make([]SimulationResult, 0, n)
 	for i := 0; i < n; i++ {
 		drawnHand := ds.simulateSingleDraw(availableCards)
-
-		// Only include valid 5-card hands
-		if len(drawnHand) == 5 {
-			value := ds.handEval.Value(drawnHand)
-			ds.results = append(ds.results, SimulationResult{
-				Hand:      drawnHand,
-				HandValue: value,
-			})
-		}
+		ds.results = append(ds.results, SimulationResult{
+			Hand:      drawnHand,
+			HandValue: ds.handEval.Value(drawnHand),
+		})
 	}
 
-	// Sort results purely by HandValue (lower is better in 2-7)
+	// Sort results purely by HandValue, best first under the variant's rules
+	rules := ds.handEval.Rules()
 	sort.Slice(ds.results, func(i, j int) bool {
-		return ds.results[i].HandValue < ds.results[j].HandValue
+		return rules.Better(ds.results[i].HandValue, ds.results[j].HandValue)
 	})
 
-	// Calculate percentiles after sorting
+	// Calculate inclusive percentiles after sorting, so that tied hands
+	// share the percentile of the last of them
 	totalHands := float64(len(ds.results))
-	for i := range ds.results {
+	for i := len(ds.results) - 1; i >= 0; i-- {
+		if i+1 < len(ds.results) && ds.results[i+1].HandValue == ds.results[i].HandValue {
+			ds.results[i].Percentile = ds.results[i+1].Percentile
+			continue
+		}
 		ds.results[i].Percentile = (float64(i) + 1) / totalHands * 100
 	}
 
-	return ds.results
+	return ds.results, nil
 }
 
 func (ds *DrawSimulator) simulateSingleDraw(availableCards []card.Card) []card.Card {
@@ -1,10 +1,17 @@
-package drawsim
+package drawsim_test
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/drawsim"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+	"github.com/dgunzy/hand-eval/pkg/report"
 )
 
 const (
@@ -29,11 +36,17 @@ func TestDrawSimulator(t *testing.T) {
 		dead := []card.Card{
 			card.NewCard(card.Spades, card.King),
 		}
-		sim := NewSimulator(kept, dead, 1)
-		results := sim.RunSimulation(20)
+		sim, err := drawsim.NewValidatedSimulator(kept, dead, 1)
+		if err != nil {
+			t.Fatalf("Unexpected validation error: %v", err)
+		}
+		results, err := sim.RunSimulation(20)
+		if err != nil {
+			t.Fatalf("Unexpected simulation error: %v", err)
+		}
 
 		fmt.Printf("\n=== Test 1: 8763(draw1) Distribution ===\n")
-		printDetailedDistribution(results)
+		printReport(t, results)
 
 		// Verify that best hand has a lower value than worst hand
 		if results[0].HandValue >= results[len(results)-1].HandValue {
@@ -51,18 +64,24 @@ func TestDrawSimulator(t *testing.T) {
 		dead := []card.Card{
 			card.NewCard(card.Spades, card.Seven),
 		}
-		sim := NewSimulator(kept, dead, 1)
-		results := sim.RunSimulation(20)
+		sim, err := drawsim.NewValidatedSimulator(kept, dead, 1)
+		if err != nil {
+			t.Fatalf("Unexpected validation error: %v", err)
+		}
+		results, err := sim.RunSimulation(20)
+		if err != nil {
+			t.Fatalf("Unexpected simulation error: %v", err)
+		}
 
 		fmt.Printf("\n=== Test 2: 2345(draw1) Distribution ===\n")
 		fmt.Printf("Looking for seven draws, one seven blocked\n")
-		printDetailedDistribution(results)
+		printReport(t, results)
 
 		// Verify straight penalties
 		for _, result := range results {
 			if isSequential(ranks(result.Hand)) && uint64(result.HandValue) < straightPenalty {
 				t.Errorf("Found wheel straight without straight penalty: %v (Hash: %d)",
-					formatHand(result.Hand), result.HandValue)
+					deck.FormatCards(result.Hand), result.HandValue)
 			}
 		}
 	})
@@ -75,12 +94,18 @@ func TestDrawSimulator(t *testing.T) {
 			card.NewCard(card.Spades, card.Eight),
 		}
 		dead := []card.Card{}
-		sim := NewSimulator(kept, dead, 1)
-		results := sim.RunSimulation(20)
+		sim, err := drawsim.NewValidatedSimulator(kept, dead, 1)
+		if err != nil {
+			t.Fatalf("Unexpected validation error: %v", err)
+		}
+		results, err := sim.RunSimulation(20)
+		if err != nil {
+			t.Fatalf("Unexpected simulation error: %v", err)
+		}
 
 		fmt.Printf("\n=== Test 4: 3468s(draw1) Distribution ===\n")
 		fmt.Printf("Testing flush draws - all cards spades\n")
-		printDetailedDistribution(results)
+		printReport(t, results)
 
 		// Verify flush penalties
 		for _, result := range results {
@@ -92,7 +117,7 @@ func TestDrawSimulator(t *testing.T) {
 			}
 			if spadeCount == 5 && uint64(result.HandValue) < flushPenalty {
 				t.Errorf("Found flush without flush penalty: %v (Hash: %d)",
-					formatHand(result.Hand), result.HandValue)
+					deck.FormatCards(result.Hand), result.HandValue)
 			}
 		}
 	})
@@ -105,18 +130,24 @@ func TestDrawSimulator(t *testing.T) {
 			card.NewCard(card.Clubs, card.Seven),
 		}
 		dead := []card.Card{}
-		sim := NewSimulator(kept, dead, 1)
-		results := sim.RunSimulation(20)
+		sim, err := drawsim.NewValidatedSimulator(kept, dead, 1)
+		if err != nil {
+			t.Fatalf("Unexpected validation error: %v", err)
+		}
+		results, err := sim.RunSimulation(20)
+		if err != nil {
+			t.Fatalf("Unexpected simulation error: %v", err)
+		}
 
 		fmt.Printf("\n=== Test 5: 4567(draw1) Distribution ===\n")
 		fmt.Printf("Testing straight draws\n")
-		printDetailedDistribution(results)
+		printReport(t, results)
 
 		// Verify straight penalties
 		for _, result := range results {
 			if isSequential(ranks(result.Hand)) && uint64(result.HandValue) < straightPenalty {
 				t.Errorf("Found straight without straight penalty: %v (Hash: %d)",
-					formatHand(result.Hand), result.HandValue)
+					deck.FormatCards(result.Hand), result.HandValue)
 			}
 		}
 	})
@@ -128,29 +159,81 @@ func TestDrawSimulator(t *testing.T) {
 			card.NewCard(card.Diamonds, card.Five),
 		}
 		dead := []card.Card{}
-		sim := NewSimulator(kept, dead, 2)
-		results := sim.RunSimulation(20)
+		sim, err := drawsim.NewValidatedSimulator(kept, dead, 2)
+		if err != nil {
+			t.Fatalf("Unexpected validation error: %v", err)
+		}
+		results, err := sim.RunSimulation(20)
+		if err != nil {
+			t.Fatalf("Unexpected simulation error: %v", err)
+		}
 
 		fmt.Printf("\n=== Test 6: 335(draw2) Distribution ===\n")
 		fmt.Printf("Testing pair evaluations\n")
-		printDetailedDistribution(results)
+		printReport(t, results)
 
 		// Verify pair penalties
 		for _, result := range results {
 			if hasPair(ranks(result.Hand)) && uint64(result.HandValue) < pairPenalty {
 				t.Errorf("Found pair without pair penalty: %v (Hash: %d)",
-					formatHand(result.Hand), result.HandValue)
+					deck.FormatCards(result.Hand), result.HandValue)
 			}
 		}
 	})
 }
 
+func TestSimulatorValidation(t *testing.T) {
+	eight := card.NewCard(card.Spades, card.Eight)
+	seven := card.NewCard(card.Hearts, card.Seven)
+	six := card.NewCard(card.Diamonds, card.Six)
+	three := card.NewCard(card.Clubs, card.Three)
+
+	t.Run("Duplicate Card", func(t *testing.T) {
+		_, err := drawsim.NewValidatedSimulator([]card.Card{eight, seven, six, three}, []card.Card{eight}, 1)
+		var dupErr *drawsim.DuplicateCardError
+		if !errors.As(err, &dupErr) || dupErr.Card != eight {
+			t.Errorf("Expected drawsim.DuplicateCardError for %v, got %v", eight, err)
+		}
+	})
+
+	t.Run("Invalid Draw Count", func(t *testing.T) {
+		_, err := drawsim.NewValidatedSimulator([]card.Card{eight, seven, six, three}, nil, 2)
+		var drawErr *drawsim.InvalidDrawCountError
+		if !errors.As(err, &drawErr) {
+			t.Errorf("Expected drawsim.InvalidDrawCountError, got %v", err)
+		}
+	})
+
+	t.Run("Insufficient Deck", func(t *testing.T) {
+		dead := make([]card.Card, 0, 50)
+		for i := 0; i < 50; i++ {
+			dead = append(dead, card.Card(i))
+		}
+		_, err := drawsim.NewValidatedSimulator(nil, dead, 5)
+		var deckErr *drawsim.InsufficientDeckError
+		if !errors.As(err, &deckErr) || deckErr.Available != 2 {
+			t.Errorf("Expected drawsim.InsufficientDeckError with 2 cards available, got %v", err)
+		}
+	})
+
+	t.Run("RunSimulation Error", func(t *testing.T) {
+		sim := drawsim.NewSimulator([]card.Card{eight, seven, six, three}, []card.Card{three}, 1)
+		if results, err := sim.RunSimulation(10); err == nil {
+			t.Errorf("Expected error for overlapping cards, got %d results", len(results))
+		}
+	})
+}
+
 // Helper functions
 
+// ranks returns 2-7 ranks, with the ace playing high
 func ranks(hand []card.Card) []int {
 	ranks := make([]int, len(hand))
 	for i, c := range hand {
 		ranks[i] = int(c.Rank())
+		if c.Rank() == card.Ace {
+			ranks[i] = 13
+		}
 	}
 	return ranks
 }
@@ -187,61 +270,16 @@ func isSequential(ranks []int) bool {
 	return true
 }
 
-// Existing helper functions remain the same
-func printDetailedDistribution(results []SimulationResult) {
-	if len(results) == 0 {
-		return
+// printReport renders the outcomes of results with the report package
+func printReport(t *testing.T, results []drawsim.SimulationResult) {
+	t.Helper()
+	values := make([]handrank.HandValue, len(results))
+	for i, r := range results {
+		values[i] = r.HandValue
 	}
-
-	// Header
-	fmt.Printf("\nHand Rankings (Best to Worst)\n")
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	fmt.Printf("%-3s  %-25s  %-12s  %-10s\n", "Rank", "Hand", "Value", "Percentile")
-	fmt.Printf("────────────────────────────────────────────────────\n")
-
-	// Print each hand with ranking
-	for i, result := range results {
-		fmt.Printf("%-3d  %-25s  %-12d  %6.1f%%\n",
-			i+1,
-			formatHand(result.Hand),
-			result.HandValue,
-			result.Percentile)
-	}
-
-	// Summary statistics with clear separation
-	fmt.Printf("\nSummary Statistics\n")
-	fmt.Printf("━━━━━━━━━━━━━━━━━\n")
-	fmt.Printf("Best Hand:       %-25s  Value: %-d\n",
-		formatHand(results[0].Hand),
-		results[0].HandValue)
-	fmt.Printf("Worst Hand:      %-25s  Value: %-d\n",
-		formatHand(results[len(results)-1].Hand),
-		results[len(results)-1].HandValue)
-
-	// Percentile markers
-	fmt.Printf("\nPercentile Distribution\n")
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━\n")
-	markers := []int{25, 50, 75}
-	for _, p := range markers {
-		idx := (p * len(results)) / 100
-		if idx >= len(results) {
-			idx = len(results) - 1
-		}
-		fmt.Printf("%-3d%%:           %-25s  Value: %-d\n",
-			p,
-			formatHand(results[idx].Hand),
-			results[idx].HandValue)
+	dist := drawsim.NewDistribution(deucelowsingle.NewHashTable().Rules(), values)
+	if err := report.CategoryTable(os.Stdout, dist, report.DeuceSevenLabel, report.DefaultOptions()); err != nil {
+		t.Fatalf("Unexpected report error: %v", err)
 	}
 	fmt.Println()
 }
-
-func formatHand(hand []card.Card) string {
-	result := ""
-	for i, c := range hand {
-		if i > 0 {
-			result += " "
-		}
-		result += c.String()
-	}
-	return result
-}
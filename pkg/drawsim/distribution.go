@@ -0,0 +1,175 @@
+package drawsim
+
+import (
+	"sort"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/suitiso"
+)
+
+// PercentileMethod chooses how tied outcomes share a percentile
+type PercentileMethod int
+
+const (
+	// Inclusive counts every outcome as good as or better than a value, so
+	// the best outcome of n distinct values sits at 100/n and the worst at
+	// 100
+	Inclusive PercentileMethod = iota
+	// MidRank counts outcomes strictly better plus half of the ties
+	MidRank
+)
+
+// Distribution is the weighted distribution of final hand values for a draw
+// situation, ordered best first. Tied values are grouped, so every lookup
+// treats identical hands identically.
+type Distribution struct {
+	rules  handrank.GameRules
+	values []handrank.HandValue
+	// cumulative[i] is the total weight of values[0..i]
+	cumulative []uint64
+	total      uint64
+}
+
+// NewDistribution builds a distribution from outcomes, each counted once
+func NewDistribution(rules handrank.GameRules, values []handrank.HandValue) *Distribution {
+	weights := make(map[handrank.HandValue]uint64, len(values))
+	for _, v := range values {
+		weights[v]++
+	}
+	return newWeightedDistribution(rules, weights)
+}
+
+// newWeightedDistribution builds a distribution from value weights
+func newWeightedDistribution(rules handrank.GameRules, weights map[handrank.HandValue]uint64) *Distribution {
+	d := &Distribution{rules: rules, values: make([]handrank.HandValue, 0, len(weights))}
+	for v := range weights {
+		d.values = append(d.values, v)
+	}
+	sort.Slice(d.values, func(i, j int) bool { return rules.Better(d.values[i], d.values[j]) })
+
+	d.cumulative = make([]uint64, len(d.values))
+	for i, v := range d.values {
+		d.total += weights[v]
+		d.cumulative[i] = d.total
+	}
+	return d
+}
+
+// Total returns the total weight of all outcomes
+func (d *Distribution) Total() uint64 {
+	return d.total
+}
+
+// Values returns the distinct outcome values, best first
+func (d *Distribution) Values() []handrank.HandValue {
+	return d.values
+}
+
+// Count returns the weight of outcomes exactly equal to v
+func (d *Distribution) Count(v handrank.HandValue) uint64 {
+	i := d.search(v)
+	if i == len(d.values) || d.values[i] != v {
+		return 0
+	}
+	return d.cumulative[i] - d.before(i)
+}
+
+// Better returns the weight of outcomes strictly better than v
+func (d *Distribution) Better(v handrank.HandValue) uint64 {
+	return d.before(d.search(v))
+}
+
+// CDF returns the fraction of outcomes as good as or better than v
+func (d *Distribution) CDF(v handrank.HandValue) float64 {
+	if d.total == 0 {
+		return 0
+	}
+	return float64(d.Better(v)+d.Count(v)) / float64(d.total)
+}
+
+// Probability returns the fraction of outcomes whose values match, such as
+// a compiled 2-7 expression like "9-low or better"
+func (d *Distribution) Probability(match func(handrank.HandValue) bool) float64 {
+	if d.total == 0 {
+		return 0
+	}
+	var n uint64
+	for i, v := range d.values {
+		if match(v) {
+			n += d.cumulative[i] - d.before(i)
+		}
+	}
+	return float64(n) / float64(d.total)
+}
+
+// Percentile returns where v ranks among the outcomes, from near 0 for the
+// best to 100 for the worst. v need not be one of the outcomes, which makes
+// this a lookup of any final hand against the distribution of a draw.
+func (d *Distribution) Percentile(v handrank.HandValue, method PercentileMethod) float64 {
+	if d.total == 0 {
+		return 0
+	}
+	better, ties := float64(d.Better(v)), float64(d.Count(v))
+	if method == MidRank {
+		return (better + ties/2) / float64(d.total) * 100
+	}
+	return (better + ties) / float64(d.total) * 100
+}
+
+// search returns the index of the first value not better than v
+func (d *Distribution) search(v handrank.HandValue) int {
+	return sort.Search(len(d.values), func(i int) bool { return !d.rules.Better(d.values[i], v) })
+}
+
+// before returns the total weight of values[0..i-1]
+func (d *Distribution) before(i int) uint64 {
+	if i == 0 {
+		return 0
+	}
+	return d.cumulative[i-1]
+}
+
+// Distribution builds the distribution of n simulated draws
+func (ds *DrawSimulator) Distribution(n int) (*Distribution, error) {
+	if n < 0 {
+		return nil, &InvalidTrialsError{Trials: n}
+	}
+	return ds.cached(n, func() (*Distribution, error) { return ds.simulate(n) })
+}
+
+func (ds *DrawSimulator) simulate(n int) (*Distribution, error) {
+	results, err := ds.RunSimulation(n)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]handrank.HandValue, len(results))
+	for i, r := range results {
+		values[i] = r.HandValue
+	}
+	return NewDistribution(ds.handEval.Rules(), values), nil
+}
+
+// Enumerate builds the exact distribution of the draw by evaluating every
+// combination of cards that can be drawn. Draws that differ only by a
+// relabelling of suits that leaves the kept and dead cards alike are
+// evaluated once and weighted by how many there are.
+func (ds *DrawSimulator) Enumerate() (*Distribution, error) {
+	return ds.cached(exactTrials, ds.enumerate)
+}
+
+func (ds *DrawSimulator) enumerate() (*Distribution, error) {
+	live, err := ds.liveCards()
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[handrank.HandValue]uint64)
+	hand := make([]card.Card, len(ds.keptCards)+ds.drawCount)
+	copy(hand, ds.keptCards)
+	suitiso.EachDraw(live, ds.drawCount, [][]card.Card{ds.keptCards}, func(drawn []card.Card, weight uint64) {
+		copy(hand[len(ds.keptCards):], drawn)
+		weights[ds.handEval.Value(hand)] += weight
+	})
+	return newWeightedDistribution(ds.handEval.Rules(), weights), nil
+}
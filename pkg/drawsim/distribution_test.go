@@ -0,0 +1,136 @@
+package drawsim
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+func TestDistributionTies(t *testing.T) {
+	rules := deucelowsingle.NewHashTable().Rules()
+	d := NewDistribution(rules, []handrank.HandValue{10, 20, 20, 20, 30})
+
+	tests := []struct {
+		value     handrank.HandValue
+		inclusive float64
+		midRank   float64
+		cdf       float64
+	}{
+		{10, 20, 10, 0.2},
+		{20, 80, 50, 0.8},
+		{30, 100, 90, 1},
+		{15, 20, 20, 0.2}, // between outcomes
+		{5, 0, 0, 0},      // better than every outcome
+	}
+	for _, tt := range tests {
+		if got := d.Percentile(tt.value, Inclusive); math.Abs(got-tt.inclusive) > 1e-9 {
+			t.Errorf("Expected inclusive percentile %.1f for %d, got %.1f", tt.inclusive, tt.value, got)
+		}
+		if got := d.Percentile(tt.value, MidRank); math.Abs(got-tt.midRank) > 1e-9 {
+			t.Errorf("Expected mid-rank percentile %.1f for %d, got %.1f", tt.midRank, tt.value, got)
+		}
+		if got := d.CDF(tt.value); math.Abs(got-tt.cdf) > 1e-9 {
+			t.Errorf("Expected CDF %.2f for %d, got %.2f", tt.cdf, tt.value, got)
+		}
+	}
+	if d.Count(20) != 3 || d.Count(15) != 0 || d.Total() != 5 {
+		t.Errorf("Unexpected counts %d, %d, total %d", d.Count(20), d.Count(15), d.Total())
+	}
+}
+
+func TestSimulationPercentileTies(t *testing.T) {
+	// Drawing one card to 7-5-4-3 gives many tied values
+	kept := []card.Card{
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Hearts, card.Four),
+		card.NewCard(card.Spades, card.Three),
+	}
+	results, err := NewSimulator(kept, nil, 1).RunSimulation(1000)
+	if err != nil {
+		t.Fatalf("Unexpected simulation error: %v", err)
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i].HandValue == results[i-1].HandValue && results[i].Percentile != results[i-1].Percentile {
+			t.Fatalf("Expected tied hands to share a percentile, got %.1f and %.1f",
+				results[i-1].Percentile, results[i].Percentile)
+		}
+	}
+	if results[len(results)-1].Percentile != 100 {
+		t.Errorf("Expected the worst hand at the 100th percentile, got %.1f", results[len(results)-1].Percentile)
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	ht := deucelowsingle.NewHashTable()
+	kept := []card.Card{
+		card.NewCard(card.Spades, card.Eight),
+		card.NewCard(card.Hearts, card.Seven),
+		card.NewCard(card.Hearts, card.Six),
+		card.NewCard(card.Spades, card.Three),
+	}
+	d, err := NewSimulator(kept, nil, 1, WithEvaluator(ht)).Enumerate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d.Total() != 48 {
+		t.Fatalf("Expected 48 outcomes, got %d", d.Total())
+	}
+
+	// Nine-low or better: a deuce, four, five or nine, 16 cards in all
+	nine := ht.Value([]card.Card{
+		card.NewCard(card.Clubs, card.Nine), card.NewCard(card.Spades, card.Eight),
+		card.NewCard(card.Hearts, card.Seven), card.NewCard(card.Hearts, card.Six),
+		card.NewCard(card.Spades, card.Three),
+	})
+	if got := d.CDF(nine); math.Abs(got-16.0/48) > 1e-9 {
+		t.Errorf("Expected P(9-low or better) of 16/48, got %.4f", got)
+	}
+}
+
+func TestEnumerateMatchesFullEnumeration(t *testing.T) {
+	ht := deucelowsingle.NewHashTable()
+	tests := []struct {
+		kept, dead []card.Card
+	}{
+		{nil, nil},
+		{
+			[]card.Card{card.NewCard(card.Hearts, card.Seven), card.NewCard(card.Hearts, card.Five)},
+			[]card.Card{card.NewCard(card.Clubs, card.Two), card.NewCard(card.Spades, card.Nine)},
+		},
+		{
+			[]card.Card{card.NewCard(card.Spades, card.Four), card.NewCard(card.Clubs, card.Four), card.NewCard(card.Spades, card.Two)},
+			[]card.Card{card.NewCard(card.Diamonds, card.Six)},
+		},
+	}
+	for _, tt := range tests {
+		if len(tt.kept) < 2 && testing.Short() {
+			continue
+		}
+		ds := NewSimulator(tt.kept, tt.dead, 5-len(tt.kept), WithEvaluator(ht))
+		got, err := ds.Enumerate()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		live, _ := ds.liveCards()
+		weights := make(map[handrank.HandValue]uint64)
+		hand := append(append([]card.Card(nil), tt.kept...), make([]card.Card, ds.drawCount)...)
+		var choose func(start, depth int)
+		choose = func(start, depth int) {
+			if depth == len(hand) {
+				weights[ht.Value(hand)]++
+				return
+			}
+			for i := start; i <= len(live)-(len(hand)-depth); i++ {
+				hand[depth] = live[i]
+				choose(i+1, depth+1)
+			}
+		}
+		choose(0, len(tt.kept))
+		assertSameDistribution(t, tt.kept, got, newWeightedDistribution(ht.Rules(), weights))
+	}
+}
@@ -0,0 +1,321 @@
+package drawsim
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+)
+
+// drawTableMagic starts every draw table file, followed by a format version
+const (
+	drawTableMagic   = "DRWT"
+	drawTableVersion = 2
+)
+
+// drawKey identifies a kept-card pattern up to suit isomorphism. With no
+// other cards known, the outcome of a draw depends only on the kept ranks
+// and on whether the kept cards can still make a flush, which needs them
+// all to share one suit.
+type drawKey struct {
+	kept   uint8
+	suited bool
+	index  uint32
+}
+
+// DrawTable holds the exact outcome distribution of every draw from the
+// standard 52-card deck, for each kept rank pattern and draw count up to a
+// maximum. Distributions count only the kept cards as removed from the deck;
+// situations with dead cards need Enumerate.
+type DrawTable struct {
+	rules       handrank.GameRules
+	fingerprint uint64
+	maxDraw     int
+	dists       map[drawKey]*Distribution
+}
+
+// UncoveredDrawError reports a lookup outside the draws a table holds
+type UncoveredDrawError struct {
+	DrawCount int
+	MaxDraw   int
+}
+
+func (e *UncoveredDrawError) Error() string {
+	return fmt.Sprintf("drawsim: draw table covers draws of up to %d cards, not %d", e.MaxDraw, e.DrawCount)
+}
+
+// GenerateDrawTable enumerates every draw of one to maxDraw cards with e.
+// Only kept cards of one suit are told apart by suit, so e must treat suits
+// as mattering only through flushes. The full 2-7 table, with draws of up to
+// five cards, takes about a second to generate.
+func GenerateDrawTable(e handrank.Evaluator, maxDraw int) (*DrawTable, error) {
+	rules := e.Rules()
+	if maxDraw < 1 || maxDraw > rules.HandSize || rules.HandSize > 5 {
+		return nil, &InvalidDrawCountError{Kept: rules.HandSize - maxDraw, DrawCount: maxDraw}
+	}
+
+	var keys []drawKey
+	var hands [][]card.Card
+	for kept := rules.HandSize - maxDraw; kept < rules.HandSize; kept++ {
+		eachRankPattern(kept, func(counts []uint8) {
+			index := handrank.EncodeRankCounts(counts)
+			distinct := true
+			for _, c := range counts {
+				distinct = distinct && c <= 1
+			}
+			if distinct {
+				keys = append(keys, drawKey{kept: uint8(kept), suited: true, index: index})
+				hands = append(hands, patternHand(counts, true))
+			}
+			if kept >= 2 {
+				keys = append(keys, drawKey{kept: uint8(kept), suited: false, index: index})
+				hands = append(hands, patternHand(counts, false))
+			}
+		})
+	}
+
+	dists := make([]*Distribution, len(keys))
+	errs := make([]error, len(keys))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				ds := NewSimulator(hands[i], nil, rules.HandSize-len(hands[i]), WithEvaluator(e), WithDeck(deck.Standard()))
+				dists[i], errs[i] = ds.Enumerate()
+			}
+		}()
+	}
+	for i := range keys {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	t := &DrawTable{rules: rules, fingerprint: fingerprint(e), maxDraw: maxDraw, dists: make(map[drawKey]*Distribution, len(keys))}
+	for i, k := range keys {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		t.dists[k] = dists[i]
+	}
+	return t, nil
+}
+
+// fingerprint hashes e's values for a fixed set of random hands, so that
+// evaluators sharing rules, such as 2-7 with and without wild deuces, can be
+// told apart
+func fingerprint(e handrank.Evaluator) uint64 {
+	h := fnv.New64a()
+	r := rand.New(rand.NewSource(1))
+	hand := make([]card.Card, e.Rules().HandSize)
+	var buf [8]byte
+	for i := 0; i < 256; i++ {
+		for j, c := range r.Perm(deck.StandardSize)[:len(hand)] {
+			hand[j] = card.Card(c)
+		}
+		binary.LittleEndian.PutUint64(buf[:], uint64(e.Value(hand)))
+		h.Write(buf[:])
+	}
+	return h.Sum64()
+}
+
+// eachRankPattern calls fn with the per-rank counts of every multiset of size
+// ranks, with at most four cards of a rank. counts is reused between calls.
+func eachRankPattern(size int, fn func(counts []uint8)) {
+	counts := make([]uint8, 13)
+	var fill func(rank, left int)
+	fill = func(rank, left int) {
+		if left == 0 {
+			fn(counts)
+			return
+		}
+		if rank == len(counts) {
+			return
+		}
+		for c := min(left, 4); c >= 0; c-- {
+			counts[rank] = uint8(c)
+			fill(rank+1, left-c)
+		}
+		counts[rank] = 0
+	}
+	fill(0, size)
+}
+
+// patternHand returns cards with the given rank counts, all spades when
+// suited and otherwise spread over the suits in turn
+func patternHand(counts []uint8, suited bool) []card.Card {
+	suits := [4]card.Suit{card.Spades, card.Hearts, card.Diamonds, card.Clubs}
+	var hand []card.Card
+	for rank, c := range counts {
+		for j := uint8(0); j < c; j++ {
+			s := suits[0]
+			if !suited {
+				s = suits[len(hand)%len(suits)]
+			}
+			hand = append(hand, card.NewCard(s, card.Rank(rank)))
+		}
+	}
+	return hand
+}
+
+// Rules returns the rules of the evaluator the table was generated with
+func (t *DrawTable) Rules() handrank.GameRules {
+	return t.rules
+}
+
+// MaxDraw returns the largest draw the table covers
+func (t *DrawTable) MaxDraw() int {
+	return t.maxDraw
+}
+
+// Lookup returns the distribution of drawing drawCount cards to kept. The
+// table holds no pat hands, so drawCount must be at least one.
+func (t *DrawTable) Lookup(kept []card.Card, drawCount int) (*Distribution, error) {
+	if drawCount < 1 || len(kept)+drawCount != t.rules.HandSize {
+		return nil, &InvalidDrawCountError{Kept: len(kept), DrawCount: drawCount}
+	}
+	if drawCount > t.maxDraw {
+		return nil, &UncoveredDrawError{DrawCount: drawCount, MaxDraw: t.maxDraw}
+	}
+	counts := make([]uint8, 13)
+	suited := true
+	for i, c := range kept {
+		if !deck.Standard().Contains(c) {
+			return nil, &InvalidCardError{Card: c}
+		}
+		if containsCard(kept[:i], c) {
+			return nil, &DuplicateCardError{Card: c}
+		}
+		counts[c.Rank()]++
+		suited = suited && c.Suit() == kept[0].Suit()
+	}
+	d, ok := t.dists[drawKey{kept: uint8(len(kept)), suited: suited, index: handrank.EncodeRankCounts(counts)}]
+	if !ok {
+		return nil, fmt.Errorf("drawsim: draw table has no entry for %v", kept)
+	}
+	return d, nil
+}
+
+// Probability returns the chance that drawing drawCount cards to kept makes
+// a hand as good as or better than target
+func (t *DrawTable) Probability(kept []card.Card, drawCount int, target handrank.HandValue) (float64, error) {
+	d, err := t.Lookup(kept, drawCount)
+	if err != nil {
+		return 0, err
+	}
+	return d.CDF(target), nil
+}
+
+// Lookup answers the simulator's draw from t. Draws the table cannot answer,
+// such as those with dead cards, from another deck or scored by an evaluator
+// other than the one the table was generated with, are enumerated exactly.
+func (ds *DrawSimulator) Lookup(t *DrawTable) (*Distribution, error) {
+	if len(ds.deadCards) == 0 && ds.cards() == deck.Standard() && ds.drawCount >= 1 && ds.drawCount <= t.maxDraw &&
+		ds.handEval.Rules() == t.rules && fingerprint(ds.handEval) == t.fingerprint {
+		if d, err := t.Lookup(ds.keptCards, ds.drawCount); err == nil {
+			return d, nil
+		}
+	}
+	return ds.Enumerate()
+}
+
+// WriteTo writes the table in its compressed file format: each distribution
+// is stored as its values, best first and delta encoded, with their counts
+func (t *DrawTable) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	zw := gzip.NewWriter(cw)
+	e := newEncoder(zw)
+
+	e.w.WriteString(drawTableMagic)
+	e.uvarint(drawTableVersion)
+	e.rules(t.rules)
+	e.uvarint(t.fingerprint)
+	e.uvarint(uint64(t.maxDraw))
+	e.uvarint(uint64(len(t.dists)))
+	// Keys are written in order so that a table always writes the same file
+	keys := make([]drawKey, 0, len(t.dists))
+	for k := range t.dists {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.kept != b.kept {
+			return a.kept < b.kept
+		}
+		if a.index != b.index {
+			return a.index < b.index
+		}
+		return !a.suited && b.suited
+	})
+	for _, k := range keys {
+		e.uvarint(uint64(k.kept))
+		e.boolean(k.suited)
+		e.uvarint(uint64(k.index))
+		e.distribution(t.dists[k])
+	}
+
+	if err := e.w.Flush(); err != nil {
+		return cw.n, err
+	}
+	err := zw.Close()
+	return cw.n, err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// ReadDrawTable reads a table written by WriteTo
+func ReadDrawTable(r io.Reader) (*DrawTable, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("drawsim: reading draw table: %w", err)
+	}
+	defer zr.Close()
+	d := newDecoder(zr)
+
+	magic := make([]byte, len(drawTableMagic))
+	if _, err := io.ReadFull(d.r, magic); err != nil || string(magic) != drawTableMagic {
+		return nil, errors.New("drawsim: not a draw table")
+	}
+	if v := d.uvarint(); d.err == nil && v != drawTableVersion {
+		return nil, fmt.Errorf("drawsim: unsupported draw table version %d", v)
+	}
+	t := &DrawTable{rules: d.rules(), fingerprint: d.uvarint()}
+	t.maxDraw = int(d.uvarint())
+	entries := d.uvarint()
+	if d.err != nil {
+		return nil, fmt.Errorf("drawsim: reading draw table: %w", d.err)
+	}
+
+	// A corrupt count must not size the map
+	t.dists = make(map[drawKey]*Distribution, min(entries, 1<<16))
+	for i := uint64(0); i < entries && d.err == nil; i++ {
+		k := drawKey{kept: uint8(d.uvarint()), suited: d.boolean(), index: uint32(d.uvarint())}
+		t.dists[k] = d.distribution(t.rules)
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("drawsim: reading draw table: %w", d.err)
+	}
+	return t, nil
+}
@@ -0,0 +1,156 @@
+package drawsim
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+func TestDrawTable(t *testing.T) {
+	ht := deucelowsingle.NewHashTable()
+	table, err := GenerateDrawTable(ht, 2)
+	if err != nil {
+		t.Fatalf("Unexpected generation error: %v", err)
+	}
+
+	// One to 8-7-6-3 makes a nine-low or better with a deuce, four, five or
+	// nine: 16 of 48 cards
+	kept := []card.Card{
+		card.NewCard(card.Clubs, card.Eight),
+		card.NewCard(card.Diamonds, card.Seven),
+		card.NewCard(card.Hearts, card.Six),
+		card.NewCard(card.Spades, card.Three),
+	}
+	nineLow := ht.Value([]card.Card{
+		card.NewCard(card.Spades, card.Nine),
+		card.NewCard(card.Hearts, card.Eight),
+		card.NewCard(card.Hearts, card.Seven),
+		card.NewCard(card.Clubs, card.Six),
+		card.NewCard(card.Diamonds, card.Four),
+	})
+	p, err := table.Probability(kept, 1, nineLow)
+	if err != nil {
+		t.Fatalf("Unexpected lookup error: %v", err)
+	}
+	if math.Abs(p-16.0/48) > 1e-12 {
+		t.Errorf("Expected P(9-low or better) of 1/3, got %.4f", p)
+	}
+	dist, err := table.Lookup(kept, 1)
+	if err != nil {
+		t.Fatalf("Unexpected lookup error: %v", err)
+	}
+	for expr, want := range map[string]float64{"9-low or better": 16.0 / 48, "any pair or worse": 12.0 / 48, "8-7": 12.0 / 48} {
+		if got := dist.Probability(deucelowsingle.MustCompile(expr)); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Expected P(%s) of %.4f, got %.4f", expr, want, got)
+		}
+	}
+
+	// The table must match exact enumeration for suited, offsuit and paired
+	// kept cards, whatever their suits
+	situations := [][]card.Card{
+		kept,
+		{card.NewCard(card.Hearts, card.Seven), card.NewCard(card.Hearts, card.Five), card.NewCard(card.Hearts, card.Two), card.NewCard(card.Hearts, card.Four)},
+		{card.NewCard(card.Clubs, card.Seven), card.NewCard(card.Diamonds, card.Seven), card.NewCard(card.Clubs, card.Two)},
+		{card.NewCard(card.Diamonds, card.King), card.NewCard(card.Diamonds, card.Ace), card.NewCard(card.Diamonds, card.Three)},
+	}
+	for _, kept := range situations {
+		want, err := NewSimulator(kept, nil, 5-len(kept), WithEvaluator(ht)).Enumerate()
+		if err != nil {
+			t.Fatalf("Unexpected enumeration error: %v", err)
+		}
+		got, err := table.Lookup(kept, 5-len(kept))
+		if err != nil {
+			t.Fatalf("Unexpected lookup error for %v: %v", kept, err)
+		}
+		assertSameDistribution(t, kept, got, want)
+	}
+
+	if _, err := table.Lookup(kept[:2], 3); !errors.As(err, new(*UncoveredDrawError)) {
+		t.Errorf("Expected an uncovered draw error, got %v", err)
+	}
+
+	// Dead cards fall back to enumeration
+	dead := []card.Card{card.NewCard(card.Clubs, card.Nine)}
+	d, err := NewSimulator(kept, dead, 1, WithEvaluator(ht)).Lookup(table)
+	if err != nil {
+		t.Fatalf("Unexpected lookup error: %v", err)
+	}
+	if d.Total() != 47 || math.Abs(d.CDF(nineLow)-15.0/47) > 1e-12 {
+		t.Errorf("Expected 15 of 47 draws to make a nine-low, got %d of %d", d.Better(nineLow)+d.Count(nineLow), d.Total())
+	}
+
+	var buf bytes.Buffer
+	if _, err := table.WriteTo(&buf); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	read, err := ReadDrawTable(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected read error: %v", err)
+	}
+	if read.Rules() != table.Rules() || read.fingerprint != table.fingerprint || read.MaxDraw() != 2 || len(read.dists) != len(table.dists) {
+		t.Fatalf("Read table does not match the written one")
+	}
+	for k, want := range table.dists {
+		assertSameDistribution(t, k, read.dists[k], want)
+	}
+
+	// Evaluators the table was not generated with are enumerated, even when
+	// they share its rules. Drawing a second deuce to 7-5-4-2 pairs it, unless
+	// deuces are wild.
+	withDeuce := []card.Card{
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Diamonds, card.Four),
+		card.NewCard(card.Clubs, card.Two),
+	}
+	for name, e := range map[string]handrank.Evaluator{
+		"A-5":         deucelowsingle.NewAceFiveTable(),
+		"deuces wild": deucelowsingle.NewDeucesWildTable(ht),
+	} {
+		ds := NewSimulator(withDeuce, nil, 1, WithEvaluator(e))
+		want, err := ds.Enumerate()
+		if err != nil {
+			t.Fatalf("Unexpected enumeration error: %v", err)
+		}
+		got, err := ds.Lookup(read)
+		if err != nil {
+			t.Fatalf("Unexpected lookup error: %v", err)
+		}
+		assertSameDistribution(t, name, got, want)
+	}
+
+	// A corrupt entry count is an error rather than a huge allocation
+	var corrupt bytes.Buffer
+	zw := gzip.NewWriter(&corrupt)
+	e := newEncoder(zw)
+	e.w.WriteString(drawTableMagic)
+	e.uvarint(drawTableVersion)
+	e.rules(table.rules)
+	e.uvarint(table.fingerprint)
+	e.uvarint(2)
+	e.uvarint(math.MaxUint64)
+	e.w.Flush()
+	zw.Close()
+	if _, err := ReadDrawTable(&corrupt); err == nil {
+		t.Error("Expected an error reading a truncated table")
+	}
+}
+
+func assertSameDistribution(t *testing.T, what interface{}, got, want *Distribution) {
+	t.Helper()
+	if got.Total() != want.Total() || len(got.Values()) != len(want.Values()) {
+		t.Fatalf("Distributions differ for %v: %d outcomes over %d values, want %d over %d",
+			what, got.Total(), len(got.Values()), want.Total(), len(want.Values()))
+	}
+	for _, v := range want.Values() {
+		if got.Count(v) != want.Count(v) {
+			t.Fatalf("Distributions differ for %v at value %d: %d, want %d", what, v, got.Count(v), want.Count(v))
+		}
+	}
+}
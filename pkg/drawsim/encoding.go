@@ -0,0 +1,112 @@
+package drawsim
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+)
+
+// encoder writes the varint encoding shared by draw tables and the cache's
+// disk store. Write errors surface when the buffer is flushed.
+type encoder struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+}
+
+func newEncoder(w io.Writer) *encoder {
+	return &encoder{w: bufio.NewWriter(w)}
+}
+
+func (e *encoder) uvarint(x uint64) {
+	e.w.Write(e.buf[:binary.PutUvarint(e.buf[:], x)])
+}
+
+func (e *encoder) varint(x int64) {
+	e.w.Write(e.buf[:binary.PutVarint(e.buf[:], x)])
+}
+
+func (e *encoder) boolean(b bool) {
+	if b {
+		e.uvarint(1)
+	} else {
+		e.uvarint(0)
+	}
+}
+
+func (e *encoder) rules(r handrank.GameRules) {
+	e.uvarint(uint64(r.MaxCards))
+	e.uvarint(uint64(r.MinCards))
+	e.boolean(r.UseSuits)
+	e.uvarint(uint64(r.HandSize))
+	e.boolean(r.IsLowball)
+}
+
+// distribution writes the distinct values, best first and delta encoded,
+// each followed by its weight
+func (e *encoder) distribution(d *Distribution) {
+	e.uvarint(uint64(len(d.values)))
+	var prev handrank.HandValue
+	for i, v := range d.values {
+		e.varint(int64(v - prev))
+		e.uvarint(d.cumulative[i] - d.before(i))
+		prev = v
+	}
+}
+
+// decoder reads what an encoder writes. The first error is kept in err and
+// every later read returns zero.
+type decoder struct {
+	r   *bufio.Reader
+	err error
+}
+
+func newDecoder(r io.Reader) *decoder {
+	return &decoder{r: bufio.NewReader(r)}
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	var x uint64
+	x, d.err = binary.ReadUvarint(d.r)
+	return x
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	var x int64
+	x, d.err = binary.ReadVarint(d.r)
+	return x
+}
+
+func (d *decoder) boolean() bool {
+	return d.uvarint() == 1
+}
+
+func (d *decoder) rules() handrank.GameRules {
+	var r handrank.GameRules
+	r.MaxCards = int(d.uvarint())
+	r.MinCards = int(d.uvarint())
+	r.UseSuits = d.boolean()
+	r.HandSize = int(d.uvarint())
+	r.IsLowball = d.boolean()
+	return r
+}
+
+func (d *decoder) distribution(rules handrank.GameRules) *Distribution {
+	n := d.uvarint()
+	dist := &Distribution{rules: rules, values: make([]handrank.HandValue, 0, min(n, 1<<16)), cumulative: make([]uint64, 0, min(n, 1<<16))}
+	var v handrank.HandValue
+	for i := uint64(0); i < n && d.err == nil; i++ {
+		v += handrank.HandValue(d.varint())
+		dist.total += d.uvarint()
+		dist.values = append(dist.values, v)
+		dist.cumulative = append(dist.cumulative, dist.total)
+	}
+	return dist
+}
@@ -0,0 +1,78 @@
+package drawsim
+
+import (
+	"fmt"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+)
+
+// DuplicateCardError reports a card that appears more than once across the
+// kept and dead cards
+type DuplicateCardError struct {
+	Card card.Card
+}
+
+func (e *DuplicateCardError) Error() string {
+	return fmt.Sprintf("drawsim: card %v appears more than once in kept and dead cards", e.Card)
+}
+
+// InvalidCardError reports a card that is not in the variant's deck
+type InvalidCardError struct {
+	Card card.Card
+}
+
+func (e *InvalidCardError) Error() string {
+	return fmt.Sprintf("drawsim: card code %d is not in the deck", int(e.Card))
+}
+
+// UnscorableCardError reports a card in a configured deck that the
+// evaluator cannot score, such as a joker without a wild-card evaluator
+type UnscorableCardError struct {
+	Card card.Card
+}
+
+func (e *UnscorableCardError) Error() string {
+	return fmt.Sprintf("drawsim: card code %d in the deck cannot be scored by the evaluator", int(e.Card))
+}
+
+// InvalidDrawCountError reports a draw count that does not complete a
+// five-card hand from the kept cards
+type InvalidDrawCountError struct {
+	Kept      int
+	DrawCount int
+}
+
+func (e *InvalidDrawCountError) Error() string {
+	return fmt.Sprintf("drawsim: keeping %d cards and drawing %d does not make a complete hand",
+		e.Kept, e.DrawCount)
+}
+
+// InsufficientDeckError reports a deck with too few live cards for the draw
+type InsufficientDeckError struct {
+	Needed    int
+	Available int
+}
+
+func (e *InsufficientDeckError) Error() string {
+	return fmt.Sprintf("drawsim: need %d cards, only %d remain", e.Needed, e.Available)
+}
+
+// InvalidTrialsError reports a negative number of simulation trials
+type InvalidTrialsError struct {
+	Trials int
+}
+
+func (e *InvalidTrialsError) Error() string {
+	return fmt.Sprintf("drawsim: invalid trial count %d", e.Trials)
+}
+
+// UnsupportedDiscardError reports an evaluator whose players have no discard
+// strategy to simulate draws with
+type UnsupportedDiscardError struct {
+	Evaluator handrank.Evaluator
+}
+
+func (e *UnsupportedDiscardError) Error() string {
+	return fmt.Sprintf("drawsim: no discard strategy for evaluator %T; set one with WithDiscardStrategy", e.Evaluator)
+}
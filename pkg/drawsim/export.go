@@ -0,0 +1,345 @@
+package drawsim
+
+import (
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+)
+
+// Format is a file format for exporting results and distributions
+type Format int
+
+const (
+	// JSONLines writes one JSON object per result or distinct value
+	JSONLines Format = iota
+	// CSV writes a header row, then one row per result with a column for
+	// each card in notation such as "7s", or one row per distinct value
+	CSV
+	// Columnar is a compact binary format storing each field as a column
+	Columnar
+)
+
+func (f Format) String() string {
+	switch f {
+	case JSONLines:
+		return "jsonl"
+	case CSV:
+		return "csv"
+	case Columnar:
+		return "columnar"
+	}
+	return "unknown"
+}
+
+const (
+	resultsMagic      = "DRWR"
+	distributionMagic = "DRWD"
+	columnarVersion   = 1
+)
+
+type resultRow struct {
+	Hand       string  `json:"hand"`
+	Value      uint64  `json:"value"`
+	Percentile float64 `json:"percentile"`
+}
+
+type distributionRow struct {
+	Value uint64 `json:"value"`
+	Count uint64 `json:"count"`
+}
+
+// WriteResults writes simulation results in format f
+func WriteResults(w io.Writer, f Format, results []SimulationResult) error {
+	switch f {
+	case JSONLines:
+		enc := json.NewEncoder(w)
+		for _, r := range results {
+			if err := enc.Encode(resultRow{deck.FormatCards(r.Hand), uint64(r.HandValue), r.Percentile}); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case CSV:
+		cw := csv.NewWriter(w)
+		width := 0
+		for _, r := range results {
+			width = max(width, len(r.Hand))
+		}
+		header := make([]string, 0, width+2)
+		for i := 1; i <= width; i++ {
+			header = append(header, "card"+strconv.Itoa(i))
+		}
+		cw.Write(append(header, "value", "percentile"))
+		row := make([]string, width+2)
+		for _, r := range results {
+			for i := 0; i < width; i++ {
+				row[i] = ""
+				if i < len(r.Hand) {
+					row[i] = deck.FormatCard(r.Hand[i])
+				}
+			}
+			row[width] = strconv.FormatUint(uint64(r.HandValue), 10)
+			row[width+1] = strconv.FormatFloat(r.Percentile, 'g', -1, 64)
+			cw.Write(row)
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case Columnar:
+		e := newEncoder(w)
+		e.w.WriteString(resultsMagic)
+		e.uvarint(columnarVersion)
+		e.uvarint(uint64(len(results)))
+		for _, r := range results {
+			e.uvarint(uint64(len(r.Hand)))
+		}
+		for _, r := range results {
+			for _, c := range r.Hand {
+				e.w.WriteByte(byte(c))
+			}
+		}
+		var prev handrank.HandValue
+		for _, r := range results {
+			e.varint(int64(r.HandValue - prev))
+			prev = r.HandValue
+		}
+		for _, r := range results {
+			binary.Write(e.w, binary.LittleEndian, math.Float64bits(r.Percentile))
+		}
+		return e.w.Flush()
+	}
+	return fmt.Errorf("drawsim: unknown export format %d", int(f))
+}
+
+// ReadResults reads simulation results written by WriteResults in format f
+func ReadResults(r io.Reader, f Format) ([]SimulationResult, error) {
+	switch f {
+	case JSONLines:
+		var results []SimulationResult
+		dec := json.NewDecoder(r)
+		for {
+			var row resultRow
+			if err := dec.Decode(&row); err == io.EOF {
+				return results, nil
+			} else if err != nil {
+				return nil, fmt.Errorf("drawsim: reading results: %w", err)
+			}
+			hand, err := deck.ParseCards(row.Hand)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, SimulationResult{Hand: hand, HandValue: handrank.HandValue(row.Value), Percentile: row.Percentile})
+		}
+
+	case CSV:
+		rows, err := csv.NewReader(r).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("drawsim: reading results: %w", err)
+		}
+		if len(rows) == 0 || len(rows[0]) < 2 {
+			return nil, errors.New("drawsim: reading results: missing header")
+		}
+		width := len(rows[0]) - 2
+		results := make([]SimulationResult, 0, len(rows)-1)
+		for _, row := range rows[1:] {
+			var res SimulationResult
+			for _, s := range row[:width] {
+				if s == "" {
+					continue
+				}
+				c, err := deck.ParseCard(s)
+				if err != nil {
+					return nil, err
+				}
+				res.Hand = append(res.Hand, c)
+			}
+			v, err := strconv.ParseUint(row[width], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("drawsim: reading results: %w", err)
+			}
+			if res.Percentile, err = strconv.ParseFloat(row[width+1], 64); err != nil {
+				return nil, fmt.Errorf("drawsim: reading results: %w", err)
+			}
+			res.HandValue = handrank.HandValue(v)
+			results = append(results, res)
+		}
+		return results, nil
+
+	case Columnar:
+		d := newDecoder(r)
+		if err := d.magic(resultsMagic, "results"); err != nil {
+			return nil, err
+		}
+		n := d.uvarint()
+		if d.err != nil {
+			return nil, fmt.Errorf("drawsim: reading results: %w", d.err)
+		}
+		results := make([]SimulationResult, 0, min(n, 1<<20))
+		for i := uint64(0); i < n && d.err == nil; i++ {
+			size := d.uvarint()
+			if size > deck.StandardSize {
+				return nil, fmt.Errorf("drawsim: reading results: invalid hand size %d", size)
+			}
+			results = append(results, SimulationResult{Hand: make([]card.Card, size)})
+		}
+		for i := range results {
+			for j := range results[i].Hand {
+				b, err := d.r.ReadByte()
+				if err != nil && d.err == nil {
+					d.err = err
+				}
+				results[i].Hand[j] = card.Card(b)
+			}
+		}
+		var v handrank.HandValue
+		for i := range results {
+			v += handrank.HandValue(d.varint())
+			results[i].HandValue = v
+		}
+		for i := range results {
+			var bits uint64
+			if err := binary.Read(d.r, binary.LittleEndian, &bits); err != nil && d.err == nil {
+				d.err = err
+			}
+			results[i].Percentile = math.Float64frombits(bits)
+		}
+		if d.err != nil {
+			return nil, fmt.Errorf("drawsim: reading results: %w", d.err)
+		}
+		return results, nil
+	}
+	return nil, fmt.Errorf("drawsim: unknown export format %d", int(f))
+}
+
+// WriteDistribution writes each distinct value of d with its count, best
+// first, in format f
+func WriteDistribution(w io.Writer, f Format, d *Distribution) error {
+	switch f {
+	case JSONLines:
+		enc := json.NewEncoder(w)
+		for _, v := range d.values {
+			if err := enc.Encode(distributionRow{uint64(v), d.Count(v)}); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case CSV:
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"value", "count", "cdf"})
+		for i, v := range d.values {
+			// Values read with zero counts leave an empty distribution
+			cdf := 0.0
+			if d.total > 0 {
+				cdf = float64(d.cumulative[i]) / float64(d.total)
+			}
+			cw.Write([]string{
+				strconv.FormatUint(uint64(v), 10),
+				strconv.FormatUint(d.cumulative[i]-d.before(i), 10),
+				strconv.FormatFloat(cdf, 'g', -1, 64),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case Columnar:
+		e := newEncoder(w)
+		e.w.WriteString(distributionMagic)
+		e.uvarint(columnarVersion)
+		e.uvarint(uint64(len(d.values)))
+		var prev handrank.HandValue
+		for _, v := range d.values {
+			e.varint(int64(v - prev))
+			prev = v
+		}
+		for i := range d.values {
+			e.uvarint(d.cumulative[i] - d.before(i))
+		}
+		return e.w.Flush()
+	}
+	return fmt.Errorf("drawsim: unknown export format %d", int(f))
+}
+
+// ReadDistribution reads a distribution written by WriteDistribution in
+// format f, ordering its values by rules
+func ReadDistribution(r io.Reader, f Format, rules handrank.GameRules) (*Distribution, error) {
+	weights := make(map[handrank.HandValue]uint64)
+	switch f {
+	case JSONLines:
+		dec := json.NewDecoder(r)
+		for {
+			var row distributionRow
+			if err := dec.Decode(&row); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("drawsim: reading distribution: %w", err)
+			}
+			weights[handrank.HandValue(row.Value)] += row.Count
+		}
+
+	case CSV:
+		rows, err := csv.NewReader(r).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("drawsim: reading distribution: %w", err)
+		}
+		if len(rows) == 0 || len(rows[0]) < 2 {
+			return nil, errors.New("drawsim: reading distribution: missing header")
+		}
+		for _, row := range rows[1:] {
+			v, err := strconv.ParseUint(row[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("drawsim: reading distribution: %w", err)
+			}
+			n, err := strconv.ParseUint(row[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("drawsim: reading distribution: %w", err)
+			}
+			weights[handrank.HandValue(v)] += n
+		}
+
+	case Columnar:
+		d := newDecoder(r)
+		if err := d.magic(distributionMagic, "distribution"); err != nil {
+			return nil, err
+		}
+		n := d.uvarint()
+		values := make([]handrank.HandValue, 0, min(n, 1<<20))
+		var v handrank.HandValue
+		for i := uint64(0); i < n && d.err == nil; i++ {
+			v += handrank.HandValue(d.varint())
+			values = append(values, v)
+		}
+		for _, v := range values {
+			weights[v] += d.uvarint()
+		}
+		if d.err != nil {
+			return nil, fmt.Errorf("drawsim: reading distribution: %w", d.err)
+		}
+
+	default:
+		return nil, fmt.Errorf("drawsim: unknown export format %d", int(f))
+	}
+	return newWeightedDistribution(rules, weights), nil
+}
+
+// magic reads and checks a columnar file's magic string and version
+func (d *decoder) magic(want, what string) error {
+	got := make([]byte, len(want))
+	if _, err := io.ReadFull(d.r, got); err != nil || string(got) != want {
+		return fmt.Errorf("drawsim: not a columnar %s file", what)
+	}
+	if v := d.uvarint(); d.err == nil && v != columnarVersion {
+		return fmt.Errorf("drawsim: unsupported columnar version %d", v)
+	}
+	return nil
+}
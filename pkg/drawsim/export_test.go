@@ -0,0 +1,114 @@
+package drawsim
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	ht := deucelowsingle.NewHashTable()
+	kept := []card.Card{
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Diamonds, card.Four),
+	}
+	ds := NewSimulator(kept, nil, 2, WithEvaluator(ht))
+	results, err := ds.RunSimulation(200)
+	if err != nil {
+		t.Fatalf("Unexpected simulation error: %v", err)
+	}
+	dist, err := ds.Enumerate()
+	if err != nil {
+		t.Fatalf("Unexpected enumeration error: %v", err)
+	}
+
+	for _, f := range []Format{JSONLines, CSV, Columnar} {
+		var buf bytes.Buffer
+		if err := WriteResults(&buf, f, results); err != nil {
+			t.Fatalf("%v: unexpected write error: %v", f, err)
+		}
+		if f == CSV && !strings.HasPrefix(buf.String(), "card1,card2,card3,card4,card5,value,percentile\n7s,5h,4d,") {
+			t.Errorf("%v: unexpected layout %q", f, buf.String()[:60])
+		}
+		read, err := ReadResults(&buf, f)
+		if err != nil {
+			t.Fatalf("%v: unexpected read error: %v", f, err)
+		}
+		if len(read) != len(results) {
+			t.Fatalf("%v: expected %d results, got %d", f, len(results), len(read))
+		}
+		for i := range results {
+			a, b := results[i], read[i]
+			if a.HandValue != b.HandValue || a.Percentile != b.Percentile || deckString(a.Hand) != deckString(b.Hand) {
+				t.Fatalf("%v: result %d read as %+v, want %+v", f, i, b, a)
+			}
+		}
+
+		buf.Reset()
+		if err := WriteDistribution(&buf, f, dist); err != nil {
+			t.Fatalf("%v: unexpected write error: %v", f, err)
+		}
+		got, err := ReadDistribution(&buf, f, ht.Rules())
+		if err != nil {
+			t.Fatalf("%v: unexpected read error: %v", f, err)
+		}
+		assertSameDistribution(t, f, got, dist)
+	}
+
+	// Results dealt from a two-joker deck keep each joker
+	jokers := []SimulationResult{{
+		Hand:       []card.Card{deck.SecondJoker, deck.Joker, kept[0], kept[1], kept[2]},
+		HandValue:  7,
+		Percentile: 0.5,
+	}}
+	for _, f := range []Format{JSONLines, CSV, Columnar} {
+		var buf bytes.Buffer
+		if err := WriteResults(&buf, f, jokers); err != nil {
+			t.Fatalf("%v: unexpected write error: %v", f, err)
+		}
+		read, err := ReadResults(&buf, f)
+		if err != nil {
+			t.Fatalf("%v: unexpected read error: %v", f, err)
+		}
+		if len(read) != 1 {
+			t.Fatalf("%v: expected 1 result, got %d", f, len(read))
+		}
+		if !reflect.DeepEqual(read[0].Hand, jokers[0].Hand) {
+			t.Errorf("%v: expected %s, got %s", f, deck.FormatCards(jokers[0].Hand), deck.FormatCards(read[0].Hand))
+		}
+	}
+
+	if _, err := ReadResults(strings.NewReader("nope"), Columnar); err == nil {
+		t.Errorf("Expected an error reading a file that is not columnar")
+	}
+	if _, err := ReadDistribution(strings.NewReader("value\n7\n"), CSV, ht.Rules()); err == nil {
+		t.Errorf("Expected an error reading a distribution without a count column")
+	}
+
+	// A distribution of values with no outcomes has no cdf to divide out
+	empty, err := ReadDistribution(strings.NewReader("value,count\n7,0\n"), CSV, ht.Rules())
+	if err != nil {
+		t.Fatalf("Unexpected read error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteDistribution(&buf, CSV, empty); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if strings.Contains(buf.String(), "NaN") {
+		t.Errorf("Expected no NaN in an empty distribution's cdf, got %q", buf.String())
+	}
+}
+
+func deckString(cards []card.Card) string {
+	var b strings.Builder
+	for _, c := range cards {
+		b.WriteString(c.String())
+	}
+	return b.String()
+}
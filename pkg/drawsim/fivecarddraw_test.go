@@ -0,0 +1,43 @@
+package drawsim
+
+import (
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/fivecarddraw"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/highhand"
+)
+
+func TestFiveCardDrawHigh(t *testing.T) {
+	eval := fivecarddraw.NewHashTable()
+	kept := []card.Card{
+		card.NewCard(card.Spades, card.Ace),
+		card.NewCard(card.Hearts, card.Ace),
+		card.NewCard(card.Diamonds, card.Ace),
+	}
+	sim, err := NewValidatedSimulator(kept, nil, 2, WithEvaluator(eval))
+	if err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	results, err := sim.RunSimulation(500)
+	if err != nil {
+		t.Fatalf("Unexpected simulation error: %v", err)
+	}
+
+	// Higher values are better, so results must run from high to low
+	for i := 1; i < len(results); i++ {
+		if results[i-1].HandValue < results[i].HandValue {
+			t.Fatalf("Results not sorted best first at %d: %d before %d",
+				i, results[i-1].HandValue, results[i].HandValue)
+		}
+	}
+	for _, result := range results {
+		if highhand.CategoryOf(result.HandValue) < highhand.Trips {
+			t.Fatalf("Drawing two to trip aces cannot finish below trips: %v", deck.FormatCards(result.Hand))
+		}
+	}
+	if results[len(results)-1].Percentile != 100 {
+		t.Errorf("Expected the worst hand at the 100th percentile, got %.1f", results[len(results)-1].Percentile)
+	}
+}
@@ -0,0 +1,59 @@
+package drawsim
+
+import (
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+func FuzzNewSimulator(f *testing.F) {
+	f.Add([]byte{0, 1, 2, 3}, []byte{4}, 1)
+	f.Add([]byte{0, 1, 2}, []byte{}, 2)
+	f.Add([]byte{0, 0, 0, 0}, []byte{0}, 1)
+	f.Add([]byte{}, make([]byte, 50), 5)
+	f.Add([]byte{0, 1, 2, 3}, []byte{200, 255}, 47)
+	f.Add([]byte{0, 1, 2, 3}, []byte{}, -1)
+
+	f.Fuzz(func(t *testing.T, keptBytes, deadBytes []byte, drawCount int) {
+		if drawCount > 64 || len(keptBytes) > 64 || len(deadBytes) > 64 {
+			return
+		}
+		kept := make([]card.Card, len(keptBytes))
+		for i, b := range keptBytes {
+			kept[i] = card.Card(b)
+		}
+		dead := make([]card.Card, len(deadBytes))
+		for i, b := range deadBytes {
+			dead[i] = card.Card(b)
+		}
+
+		results, err := NewSimulator(kept, dead, drawCount).RunSimulation(5)
+		if err != nil {
+			if _, verr := NewValidatedSimulator(kept, dead, drawCount); verr == nil {
+				t.Fatalf("RunSimulation failed with %v but validation passed", err)
+			}
+			return
+		}
+		if len(results) != 5 {
+			t.Fatalf("Expected 5 results, got %d", len(results))
+		}
+
+		used := make(map[card.Card]bool)
+		for _, c := range append(append([]card.Card(nil), kept...), dead...) {
+			used[c] = true
+		}
+		for i, result := range results {
+			if len(result.Hand) != len(kept)+drawCount {
+				t.Fatalf("Expected %d cards in result, got %d", len(kept)+drawCount, len(result.Hand))
+			}
+			for _, c := range result.Hand[len(kept):] {
+				if used[c] {
+					t.Fatalf("Drew card %v that was kept or dead", c)
+				}
+			}
+			if i > 0 && results[i-1].HandValue > result.HandValue {
+				t.Fatalf("Results not sorted at %d", i)
+			}
+		}
+	})
+}
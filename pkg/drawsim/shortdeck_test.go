@@ -0,0 +1,39 @@
+package drawsim
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/shortdeck"
+)
+
+func TestShortDeckSimulation(t *testing.T) {
+	eval := shortdeck.NewHashTable(shortdeck.Options{})
+	kept := []card.Card{
+		card.NewCard(card.Spades, card.Ace),
+		card.NewCard(card.Hearts, card.King),
+	}
+
+	sim, err := NewValidatedSimulator(kept, nil, 3, WithEvaluator(eval))
+	if err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	results, err := sim.RunSimulation(500)
+	if err != nil {
+		t.Fatalf("Unexpected simulation error: %v", err)
+	}
+	for _, result := range results {
+		for _, c := range result.Hand {
+			if !shortdeck.InDeck(c) {
+				t.Fatalf("Drew %v, which is not in the short deck", c)
+			}
+		}
+	}
+
+	_, err = NewValidatedSimulator([]card.Card{card.NewCard(card.Spades, card.Two)}, nil, 4, WithEvaluator(eval))
+	var cardErr *InvalidCardError
+	if !errors.As(err, &cardErr) {
+		t.Errorf("Expected InvalidCardError for a deuce, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package drawsim
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/stud"
+)
+
+// StudHandError reports a seat holding more known cards than its street allows
+type StudHandError struct {
+	Seat   int
+	Street stud.Street
+	Down   int
+	Up     int
+}
+
+func (e *StudHandError) Error() string {
+	return fmt.Sprintf("drawsim: seat %d shows %d down and %d up cards, more than %v allows",
+		e.Seat, e.Down, e.Up, e.Street)
+}
+
+// StudSimulator estimates each seat's share of the pot in a stud game.
+// Seats hold the cards known to the simulating player, usually their own
+// full hand and only the up cards of each opponent; unknown down cards and
+// later streets are dealt at random. Exposed cards of players who folded,
+// such as their door cards, are passed as dead cards.
+type StudSimulator struct {
+	game   stud.Game
+	street stud.Street
+	seats  []stud.Hand
+	dead   []card.Card
+	deck   deck.Deck
+	eval   *stud.Evaluator
+}
+
+// StudOption configures a StudSimulator
+type StudOption func(*StudSimulator)
+
+// WithStudDeck deals from d instead of the standard deck. Hands are always
+// scored by the stud evaluator, so d may only hold standard cards; other
+// cards are reported with an UnscorableCardError.
+func WithStudDeck(d deck.Deck) StudOption {
+	return func(s *StudSimulator) {
+		s.deck = d
+	}
+}
+
+// NewStudSimulator validates the known cards and creates a simulator for a
+// hand currently on the given street
+func NewStudSimulator(game stud.Game, street stud.Street, seats []stud.Hand, dead []card.Card, opts ...StudOption) (*StudSimulator, error) {
+	s := &StudSimulator{
+		game:   game,
+		street: street,
+		seats:  seats,
+		dead:   dead,
+		deck:   deck.Standard(),
+		eval:   stud.NewEvaluator(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	standard := deck.Standard()
+	for _, c := range s.deck.Cards() {
+		if !standard.Contains(c) {
+			return nil, &UnscorableCardError{Card: c}
+		}
+	}
+	if _, err := s.liveCards(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// liveCards returns the deck minus every known card, validating the seats
+func (s *StudSimulator) liveCards() ([]card.Card, error) {
+	if s.street < stud.ThirdStreet || s.street > stud.SeventhStreet {
+		return nil, &StudHandError{Seat: -1, Street: s.street}
+	}
+
+	used := make(map[card.Card]bool)
+	mark := func(c card.Card) error {
+		if !s.deck.Contains(c) {
+			return &InvalidCardError{Card: c}
+		}
+		if used[c] {
+			return &DuplicateCardError{Card: c}
+		}
+		used[c] = true
+		return nil
+	}
+
+	unknown := 0
+	for i, h := range s.seats {
+		if len(h.Down) > s.street.DownCards() || len(h.Up) > s.street.UpCards() {
+			return nil, &StudHandError{Seat: i, Street: s.street, Down: len(h.Down), Up: len(h.Up)}
+		}
+		for _, c := range h.Cards() {
+			if err := mark(c); err != nil {
+				return nil, err
+			}
+		}
+		unknown += int(stud.SeventhStreet) - len(h.Down) - len(h.Up)
+	}
+	for _, c := range s.dead {
+		if err := mark(c); err != nil {
+			return nil, err
+		}
+	}
+
+	live := make([]card.Card, 0, s.deck.Len())
+	for _, c := range s.deck.Cards() {
+		if !used[c] {
+			live = append(live, c)
+		}
+	}
+	if unknown > len(live) {
+		return nil, &InsufficientDeckError{Needed: unknown, Available: len(live)}
+	}
+	return live, nil
+}
+
+// RunSimulation deals n random completions of the hand to seventh street and
+// returns each seat's average share of the pot
+func (s *StudSimulator) RunSimulation(n int) ([]float64, error) {
+	if n < 0 {
+		return nil, &InvalidTrialsError{Trials: n}
+	}
+	live, err := s.liveCards()
+	if err != nil {
+		return nil, err
+	}
+
+	equity := make([]float64, len(s.seats))
+	if n == 0 {
+		return equity, nil
+	}
+
+	pool := make([]card.Card, len(live))
+	hands := make([][]card.Card, len(s.seats))
+	for trial := 0; trial < n; trial++ {
+		copy(pool, live)
+		rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+
+		next := 0
+		for i, h := range s.seats {
+			hands[i] = append(hands[i][:0], h.Cards()...)
+			for len(hands[i]) < int(stud.SeventhStreet) {
+				hands[i] = append(hands[i], pool[next])
+				next++
+			}
+		}
+
+		for i, share := range s.eval.Showdown(s.game, hands) {
+			equity[i] += share
+		}
+	}
+
+	for i := range equity {
+		equity[i] /= float64(n)
+	}
+	return equity, nil
+}
@@ -0,0 +1,88 @@
+package drawsim
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/stud"
+)
+
+func TestStudSimulator(t *testing.T) {
+	// Rolled-up aces against an opponent showing a king
+	hero := stud.Hand{
+		Down: []card.Card{card.NewCard(card.Spades, card.Ace), card.NewCard(card.Hearts, card.Ace)},
+		Up:   []card.Card{card.NewCard(card.Clubs, card.Ace)},
+	}
+	villain := stud.Hand{Up: []card.Card{card.NewCard(card.Spades, card.King)}}
+	folded := []card.Card{card.NewCard(card.Hearts, card.King), card.NewCard(card.Diamonds, card.Two)}
+
+	t.Run("Equity", func(t *testing.T) {
+		sim, err := NewStudSimulator(stud.StudHigh, stud.ThirdStreet, []stud.Hand{hero, villain}, folded)
+		if err != nil {
+			t.Fatalf("Unexpected validation error: %v", err)
+		}
+		equity, err := sim.RunSimulation(2000)
+		if err != nil {
+			t.Fatalf("Unexpected simulation error: %v", err)
+		}
+		if math.Abs(equity[0]+equity[1]-1) > 1e-9 {
+			t.Errorf("Expected equities to sum to 1, got %v", equity)
+		}
+		if equity[0] < 0.7 {
+			t.Errorf("Expected rolled-up aces to be a big favourite, got %.3f", equity[0])
+		}
+	})
+
+	t.Run("Dead Door Card Conflict", func(t *testing.T) {
+		_, err := NewStudSimulator(stud.StudHigh, stud.ThirdStreet, []stud.Hand{hero, villain}, []card.Card{villain.Up[0]})
+		var dupErr *DuplicateCardError
+		if !errors.As(err, &dupErr) {
+			t.Errorf("Expected DuplicateCardError, got %v", err)
+		}
+	})
+
+	t.Run("Too Many Cards For Street", func(t *testing.T) {
+		bad := stud.Hand{Up: []card.Card{card.NewCard(card.Diamonds, card.Nine), card.NewCard(card.Diamonds, card.Ten)}}
+		_, err := NewStudSimulator(stud.Razz, stud.ThirdStreet, []stud.Hand{hero, bad}, nil)
+		var handErr *StudHandError
+		if !errors.As(err, &handErr) || handErr.Seat != 1 {
+			t.Errorf("Expected StudHandError for seat 1, got %v", err)
+		}
+	})
+
+	t.Run("Deck", func(t *testing.T) {
+		// Known and dead cards are checked against a configured deck
+		d := deck.Standard().Without(card.NewCard(card.Diamonds, card.King), card.NewCard(card.Clubs, card.King))
+		sim, err := NewStudSimulator(stud.StudHigh, stud.ThirdStreet, []stud.Hand{hero, villain}, folded, WithStudDeck(d))
+		if err != nil {
+			t.Fatalf("Unexpected validation error: %v", err)
+		}
+		if _, err := sim.RunSimulation(100); err != nil {
+			t.Fatalf("Unexpected simulation error: %v", err)
+		}
+
+		_, err = NewStudSimulator(stud.StudHigh, stud.ThirdStreet, []stud.Hand{hero, villain}, []card.Card{card.NewCard(card.Clubs, card.King)}, WithStudDeck(d))
+		var cardErr *InvalidCardError
+		if !errors.As(err, &cardErr) {
+			t.Errorf("Expected InvalidCardError for a dead card outside the deck, got %v", err)
+		}
+
+		_, err = NewStudSimulator(stud.StudHigh, stud.ThirdStreet, []stud.Hand{hero, villain}, nil, WithStudDeck(deck.Standard().WithJokers(1)))
+		var jokerErr *UnscorableCardError
+		if !errors.As(err, &jokerErr) {
+			t.Errorf("Expected UnscorableCardError for a joker, got %v", err)
+		}
+	})
+
+	t.Run("Too Many Players", func(t *testing.T) {
+		seats := make([]stud.Hand, 8)
+		_, err := NewStudSimulator(stud.StudHiLo, stud.ThirdStreet, seats, nil)
+		var deckErr *InsufficientDeckError
+		if !errors.As(err, &deckErr) {
+			t.Errorf("Expected InsufficientDeckError for 8 players, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,343 @@
+package drawsim
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+// TableState is the full state of a draw table: every hand, each player's
+// discards, the muck and the undealt stub. When the stub runs out during a
+// draw, the cards left in it are dealt and the muck is shuffled to form a new
+// stub. The drawing player's own discards from that draw are not in the muck
+// yet, so they are never dealt straight back to them.
+type TableState struct {
+	hands      [][]card.Card
+	discards   [][]card.Card
+	folded     []bool
+	muck       []card.Card
+	stub       []card.Card
+	reshuffles int
+}
+
+// NewTableState creates a table from known hands, muck and stub. The stub is
+// dealt from the front.
+func NewTableState(hands [][]card.Card, muck, stub []card.Card) *TableState {
+	ts := &TableState{
+		hands:    make([][]card.Card, len(hands)),
+		discards: make([][]card.Card, len(hands)),
+		folded:   make([]bool, len(hands)),
+		muck:     append([]card.Card(nil), muck...),
+		stub:     append([]card.Card(nil), stub...),
+	}
+	for i, h := range hands {
+		ts.hands[i] = append([]card.Card(nil), h...)
+	}
+	return ts
+}
+
+// DealTable shuffles d and deals handSize cards to each player
+func DealTable(d deck.Deck, players, handSize int) (*TableState, error) {
+	cards := d.Cards()
+	if players*handSize > len(cards) {
+		return nil, &InsufficientDeckError{Needed: players * handSize, Available: len(cards)}
+	}
+	rand.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+	hands := make([][]card.Card, players)
+	for i := range hands {
+		hands[i] = cards[i*handSize : (i+1)*handSize]
+	}
+	return NewTableState(hands, nil, cards[players*handSize:]), nil
+}
+
+// Hand returns a player's current cards
+func (ts *TableState) Hand(player int) []card.Card {
+	return ts.hands[player]
+}
+
+// Discards returns every card a player has discarded, in order
+func (ts *TableState) Discards(player int) []card.Card {
+	return ts.discards[player]
+}
+
+// Folded reports whether a player has folded
+func (ts *TableState) Folded(player int) bool {
+	return ts.folded[player]
+}
+
+// Muck returns the discards and folded hands waiting to be reshuffled
+func (ts *TableState) Muck() []card.Card {
+	return ts.muck
+}
+
+// StubSize returns how many cards are left to deal before a reshuffle
+func (ts *TableState) StubSize() int {
+	return len(ts.stub)
+}
+
+// Reshuffles returns how many times the muck has been reshuffled
+func (ts *TableState) Reshuffles() int {
+	return ts.reshuffles
+}
+
+// Draw replaces discards in a player's hand with cards from the stub,
+// reshuffling the muck into a new stub if it runs out. It returns the cards
+// drawn.
+func (ts *TableState) Draw(player int, discards []card.Card) ([]card.Card, error) {
+	hand := ts.hands[player]
+	for i, c := range discards {
+		if !containsCard(hand, c) {
+			return nil, &InvalidCardError{Card: c}
+		}
+		if containsCard(discards[:i], c) {
+			return nil, &DuplicateCardError{Card: c}
+		}
+	}
+	if len(discards) > len(ts.stub)+len(ts.muck) {
+		return nil, &InsufficientDeckError{Needed: len(discards), Available: len(ts.stub) + len(ts.muck)}
+	}
+
+	drawn := make([]card.Card, 0, len(discards))
+	for len(drawn) < len(discards) {
+		if len(ts.stub) == 0 {
+			ts.stub, ts.muck = ts.muck, nil
+			rand.Shuffle(len(ts.stub), func(i, j int) { ts.stub[i], ts.stub[j] = ts.stub[j], ts.stub[i] })
+			ts.reshuffles++
+		}
+		n := min(len(discards)-len(drawn), len(ts.stub))
+		drawn = append(drawn, ts.stub[:n]...)
+		ts.stub = ts.stub[n:]
+	}
+
+	kept := make([]card.Card, 0, len(hand))
+	for _, c := range hand {
+		if !containsCard(discards, c) {
+			kept = append(kept, c)
+		}
+	}
+	ts.hands[player] = append(kept, drawn...)
+	ts.discards[player] = append(ts.discards[player], discards...)
+	ts.muck = append(ts.muck, discards...)
+	return drawn, nil
+}
+
+// Fold mucks a player's hand
+func (ts *TableState) Fold(player int) {
+	ts.muck = append(ts.muck, ts.hands[player]...)
+	ts.hands[player] = nil
+	ts.folded[player] = true
+}
+
+// TablePlayer is what one player knows about a seat still in the hand
+type TablePlayer struct {
+	// Known holds the cards known to be in the hand: all of the hero's
+	// cards, and usually none of an opponent's
+	Known []card.Card
+	// Draws lists how many cards the seat draws in each remaining draw
+	Draws []int
+}
+
+// TableView is one player's knowledge of a draw table
+type TableView struct {
+	Players []TablePlayer
+	// Muck holds known cards in the muck, such as the viewer's own
+	// discards and any exposed cards
+	Muck []card.Card
+	// UnknownMuck counts the muck's unseen cards: other players' discards
+	// and folded hands
+	UnknownMuck int
+}
+
+// TableSimulator plays out the remaining draws of a multi-player hand from
+// one player's view, dealing the unknown cards at random each trial
+type TableSimulator struct {
+	view    TableView
+	sim     *DrawSimulator
+	discard DiscardStrategy
+}
+
+// NewTableSimulator validates view and creates a simulator. Options set the
+// evaluator, deck and discard strategy as for DrawSimulator. Evaluators other
+// than the 2-7 HashTable need a discard strategy, or an
+// UnsupportedDiscardError is returned.
+func NewTableSimulator(view TableView, opts ...Option) (*TableSimulator, error) {
+	ts := &TableSimulator{view: view, sim: NewSimulator(nil, nil, 0, opts...)}
+	var err error
+	if ts.discard, err = ts.sim.discardStrategy(); err != nil {
+		return nil, err
+	}
+	if _, err := ts.unknownCards(); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// unknownCards returns the cards the viewer has not seen, validating the view
+func (ts *TableSimulator) unknownCards() ([]card.Card, error) {
+	d, err := ts.sim.scorableCards()
+	if err != nil {
+		return nil, err
+	}
+	handSize := ts.sim.handEval.Rules().HandSize
+	used := make(map[card.Card]bool)
+	needed := ts.view.UnknownMuck
+	for _, p := range ts.view.Players {
+		if len(p.Known) > handSize {
+			return nil, &InvalidDrawCountError{Kept: len(p.Known)}
+		}
+		for _, n := range p.Draws {
+			if n < 0 || n > handSize {
+				return nil, &InvalidDrawCountError{Kept: len(p.Known), DrawCount: n}
+			}
+		}
+		needed += handSize - len(p.Known)
+	}
+	for _, cards := range append(knownHands(ts.view), ts.view.Muck) {
+		for _, c := range cards {
+			if !d.Contains(c) {
+				return nil, &InvalidCardError{Card: c}
+			}
+			if used[c] {
+				return nil, &DuplicateCardError{Card: c}
+			}
+			used[c] = true
+		}
+	}
+
+	unknown := make([]card.Card, 0, d.Len()-len(used))
+	for _, c := range d.Cards() {
+		if !used[c] {
+			unknown = append(unknown, c)
+		}
+	}
+	if needed > len(unknown) {
+		return nil, &InsufficientDeckError{Needed: needed, Available: len(unknown)}
+	}
+	return unknown, nil
+}
+
+func knownHands(view TableView) [][]card.Card {
+	hands := make([][]card.Card, len(view.Players))
+	for i, p := range view.Players {
+		hands[i] = p.Known
+	}
+	return hands
+}
+
+// RunSimulation plays n trials and returns each seat's share of the pot at
+// showdown, with ties split evenly
+func (ts *TableSimulator) RunSimulation(n int) ([]float64, error) {
+	if n < 0 {
+		return nil, &InvalidTrialsError{Trials: n}
+	}
+	unknown, err := ts.unknownCards()
+	if err != nil {
+		return nil, err
+	}
+	equity := make([]float64, len(ts.view.Players))
+	if n == 0 || len(equity) == 0 {
+		return equity, nil
+	}
+
+	rules := ts.sim.handEval.Rules()
+	rounds := 0
+	for _, p := range ts.view.Players {
+		rounds = max(rounds, len(p.Draws))
+	}
+
+	pool := make([]card.Card, len(unknown))
+	hands := make([][]card.Card, len(ts.view.Players))
+	values := make([]handrank.HandValue, len(ts.view.Players))
+	for trial := 0; trial < n; trial++ {
+		copy(pool, unknown)
+		rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+
+		next := 0
+		for i, p := range ts.view.Players {
+			fill := rules.HandSize - len(p.Known)
+			hands[i] = append(append(hands[i][:0], p.Known...), pool[next:next+fill]...)
+			next += fill
+		}
+		muck := append(append([]card.Card(nil), ts.view.Muck...), pool[next:next+ts.view.UnknownMuck]...)
+		next += ts.view.UnknownMuck
+		table := NewTableState(hands, muck, pool[next:])
+
+		for r := 0; r < rounds; r++ {
+			for i, p := range ts.view.Players {
+				if r >= len(p.Draws) {
+					continue
+				}
+				if _, err := table.Draw(i, ts.discard(table.Hand(i), p.Draws[r])); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		for i := range ts.view.Players {
+			values[i] = ts.sim.handEval.Value(table.Hand(i))
+		}
+		for i, share := range rules.Showdown(values...).Shares {
+			equity[i] += share
+		}
+	}
+
+	for i := range equity {
+		equity[i] /= float64(n)
+	}
+	return equity, nil
+}
+
+// DiscardStrategy picks n cards to throw from a hand before a draw
+type DiscardStrategy func(hand []card.Card, n int) []card.Card
+
+// WithDiscardStrategy sets how simulated players choose their discards. It
+// is needed to simulate draws for any evaluator but the 2-7 HashTable.
+func WithDiscardStrategy(s DiscardStrategy) Option {
+	return func(ds *DrawSimulator) {
+		ds.discard = s
+	}
+}
+
+// discardStrategy returns the configured discard strategy, or the 2-7
+// strategy for the 2-7 evaluator
+func (ds *DrawSimulator) discardStrategy() (DiscardStrategy, error) {
+	if ds.discard != nil {
+		return ds.discard, nil
+	}
+	if _, ok := ds.handEval.(*deucelowsingle.HashTable); ok {
+		return DeuceSevenDiscards, nil
+	}
+	return nil, &UnsupportedDiscardError{Evaluator: ds.handEval}
+}
+
+// DeuceSevenDiscards picks n cards to throw from a 2-7 hand: cards pairing a
+// lower card first, then the highest cards, with the ace playing high
+func DeuceSevenDiscards(hand []card.Card, n int) []card.Card {
+	rank := func(c card.Card) int {
+		if c.Rank() == card.Ace {
+			return 13
+		}
+		return int(c.Rank())
+	}
+	sorted := append([]card.Card(nil), hand...)
+	sort.Slice(sorted, func(i, j int) bool { return rank(sorted[i]) < rank(sorted[j]) })
+
+	var unpaired, paired []card.Card
+	for i, c := range sorted {
+		if i > 0 && sorted[i-1].Rank() == c.Rank() {
+			paired = append(paired, c)
+		} else {
+			unpaired = append(unpaired, c)
+		}
+	}
+	// Worst first: paired cards, then unpaired cards from the top down
+	worst := paired
+	for i := len(unpaired) - 1; i >= 0; i-- {
+		worst = append(worst, unpaired[i])
+	}
+	return worst[:n]
+}
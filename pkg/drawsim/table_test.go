@@ -0,0 +1,161 @@
+package drawsim
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/fivecarddraw"
+)
+
+func TestTableReshuffle(t *testing.T) {
+	c := func(r card.Rank) card.Card { return card.NewCard(card.Spades, r) }
+	h := func(r card.Rank) card.Card { return card.NewCard(card.Hearts, r) }
+	ts := NewTableState(
+		[][]card.Card{
+			{c(card.Two), c(card.Three), c(card.Four), c(card.King), c(card.Queen)},
+			{h(card.Two), h(card.Three), h(card.Four), h(card.Five), h(card.Six)},
+		},
+		[]card.Card{c(card.Jack), c(card.Ten), c(card.Nine)},
+		[]card.Card{c(card.Eight)},
+	)
+
+	drawn, err := ts.Draw(0, []card.Card{c(card.King), c(card.Queen)})
+	if err != nil {
+		t.Fatalf("Unexpected draw error: %v", err)
+	}
+	if len(drawn) != 2 || drawn[0] != c(card.Eight) {
+		t.Errorf("Expected the last stub card and then a reshuffled card, got %v", drawn)
+	}
+	if containsCard(drawn, c(card.King)) || containsCard(drawn, c(card.Queen)) {
+		t.Errorf("Expected the player's own discards not to come back, got %v", drawn)
+	}
+	if ts.Reshuffles() != 1 || ts.StubSize() != 2 {
+		t.Errorf("Expected one reshuffle leaving 2 cards, got %d and %d", ts.Reshuffles(), ts.StubSize())
+	}
+	if len(ts.Muck()) != 2 || len(ts.Discards(0)) != 2 || len(ts.Hand(0)) != 5 {
+		t.Errorf("Expected the discards in the muck, got muck %v", ts.Muck())
+	}
+
+	_, err = ts.Draw(1, []card.Card{c(card.Two)})
+	var cardErr *InvalidCardError
+	if !errors.As(err, &cardErr) {
+		t.Errorf("Expected InvalidCardError discarding a card not held, got %v", err)
+	}
+
+	ts.Fold(1)
+	if !ts.Folded(1) || len(ts.Muck()) != 7 {
+		t.Errorf("Expected the folded hand in the muck, got %v", ts.Muck())
+	}
+}
+
+func TestTableConservesCards(t *testing.T) {
+	ts, err := DealTable(deck.Standard(), 6, 5)
+	if err != nil {
+		t.Fatalf("Unexpected deal error: %v", err)
+	}
+	for round := 0; round < 3; round++ {
+		for p := 0; p < 6; p++ {
+			if _, err := ts.Draw(p, DeuceSevenDiscards(ts.Hand(p), 3)); err != nil {
+				t.Fatalf("Unexpected draw error: %v", err)
+			}
+		}
+	}
+	if ts.Reshuffles() == 0 {
+		t.Error("Expected six players drawing three cards three times to force a reshuffle")
+	}
+
+	seen := make(map[card.Card]bool)
+	all := append(append([]card.Card(nil), ts.Muck()...), ts.stub...)
+	for p := 0; p < 6; p++ {
+		all = append(all, ts.Hand(p)...)
+	}
+	for _, c := range all {
+		if seen[c] {
+			t.Fatalf("Card %v is in two places", c)
+		}
+		seen[c] = true
+	}
+	if len(seen) != deck.StandardSize {
+		t.Errorf("Expected all 52 cards accounted for, got %d", len(seen))
+	}
+}
+
+func TestTableSimulator(t *testing.T) {
+	hero := []card.Card{
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Hearts, card.Four),
+		card.NewCard(card.Spades, card.Three),
+		card.NewCard(card.Hearts, card.Two),
+	}
+	view := TableView{
+		Players: []TablePlayer{
+			{Known: hero, Draws: []int{0, 0}},
+			{Draws: []int{3, 2}},
+			{Draws: []int{2, 1}},
+		},
+		Muck:        []card.Card{card.NewCard(card.Clubs, card.Two)},
+		UnknownMuck: 4,
+	}
+	sim, err := NewTableSimulator(view)
+	if err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	equity, err := sim.RunSimulation(2000)
+	if err != nil {
+		t.Fatalf("Unexpected simulation error: %v", err)
+	}
+	var total float64
+	for _, e := range equity {
+		total += e
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("Expected equities to sum to 1, got %f", total)
+	}
+	if equity[0] < 0.9 {
+		t.Errorf("Expected the pat nuts to win almost always, got %.3f", equity[0])
+	}
+
+	view.Muck = append(view.Muck, hero[0])
+	_, err = NewTableSimulator(view)
+	var dupErr *DuplicateCardError
+	if !errors.As(err, &dupErr) {
+		t.Errorf("Expected DuplicateCardError, got %v", err)
+	}
+}
+
+func TestTableSimulatorDiscardStrategy(t *testing.T) {
+	view := TableView{
+		Players: []TablePlayer{
+			{Draws: []int{1}},
+			{Draws: []int{3}},
+		},
+	}
+
+	// The 2-7 discards would throw a high-hand player's pairs
+	high := WithEvaluator(fivecarddraw.NewHashTable())
+	_, err := NewTableSimulator(view, high)
+	var discardErr *UnsupportedDiscardError
+	if !errors.As(err, &discardErr) {
+		t.Errorf("Expected UnsupportedDiscardError for five-card draw high, got %v", err)
+	}
+
+	calls := 0
+	throwFirst := func(hand []card.Card, n int) []card.Card {
+		calls++
+		return hand[:n]
+	}
+	sim, err := NewTableSimulator(view, high, WithDiscardStrategy(throwFirst))
+	if err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	if _, err := sim.RunSimulation(10); err != nil {
+		t.Fatalf("Unexpected simulation error: %v", err)
+	}
+	if calls != 20 {
+		t.Errorf("Expected the strategy to pick both players' discards in 10 trials, got %d calls", calls)
+	}
+}
@@ -0,0 +1,57 @@
+package handhistory
+
+import (
+	"fmt"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+)
+
+// ParseError reports a line of a hand history that could not be read
+type ParseError struct {
+	HandID string
+	Line   int
+	Text   string
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	if e.Text == "" {
+		return fmt.Sprintf("handhistory: line %d: %s", e.Line, e.Reason)
+	}
+	return fmt.Sprintf("handhistory: hand %s line %d %q: %s", e.HandID, e.Line, e.Text, e.Reason)
+}
+
+// UnsupportedGameError reports a hand history for a game other than 2-7
+// single or triple draw
+type UnsupportedGameError struct {
+	Description string
+}
+
+func (e *UnsupportedGameError) Error() string {
+	return fmt.Sprintf("handhistory: unsupported game %q", e.Description)
+}
+
+// CardError reports cards in a hand history that are inconsistent with each
+// other, such as a card held twice or a discard the player did not hold
+type CardError struct {
+	HandID string
+	Player string
+	Card   card.Card
+	Reason string
+}
+
+func (e *CardError) Error() string {
+	return fmt.Sprintf("handhistory: hand %s: %s %s %s", e.HandID, e.Player, e.Reason, deck.FormatCard(e.Card))
+}
+
+// ConsistencyError reports a hand history whose structure does not add up,
+// such as a draw with the wrong number of replacement cards
+type ConsistencyError struct {
+	HandID string
+	Reason string
+}
+
+func (e *ConsistencyError) Error() string {
+	return fmt.Sprintf("handhistory: hand %s: %s", e.HandID, e.Reason)
+}
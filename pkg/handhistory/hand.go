@@ -0,0 +1,193 @@
+// Package handhistory reads PokerStars and GGPoker style text hand histories
+// for 2-7 single draw and triple draw into structured hands.
+package handhistory
+
+import (
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+// Game is a 2-7 draw game
+type Game int
+
+const (
+	SingleDraw Game = iota
+	TripleDraw
+)
+
+func (g Game) String() string {
+	switch g {
+	case SingleDraw:
+		return "2-7 Single Draw"
+	case TripleDraw:
+		return "2-7 Triple Draw"
+	}
+	return "Unknown"
+}
+
+// Draws returns how many draws the game has
+func (g Game) Draws() int {
+	if g == TripleDraw {
+		return 3
+	}
+	return 1
+}
+
+// ActionKind is the kind of a player action
+type ActionKind int
+
+const (
+	PostSmallBlind ActionKind = iota
+	PostBigBlind
+	PostAnte
+	Fold
+	Check
+	Call
+	Bet
+	Raise
+	UncalledBet
+	Collect
+)
+
+var actionNames = [...]string{
+	"posts small blind", "posts big blind", "posts ante", "folds", "checks",
+	"calls", "bets", "raises", "uncalled bet returned", "collects",
+}
+
+func (k ActionKind) String() string {
+	if k < PostSmallBlind || k > Collect {
+		return "unknown"
+	}
+	return actionNames[k]
+}
+
+// Player is a seated player and the chips they started the hand with
+type Player struct {
+	Seat  int
+	Name  string
+	Stack int64
+}
+
+// Action is a betting action. Amounts are in hundredths of the currency or
+// chip unit, so $10.50 is 1050 and 1,500 chips is 150000.
+type Action struct {
+	Player string
+	// Round is the betting round, 0 before the first draw and n after the
+	// n-th draw
+	Round int
+	Kind  ActionKind
+	// Amount is the chips put in by the action, or returned or collected
+	Amount int64
+	// To is the total bet a raise makes
+	To    int64
+	AllIn bool
+}
+
+// Draw is one player's discard and replacement in a draw round. Discarded
+// and Drawn are only known for the hero or when the player's hand is shown.
+type Draw struct {
+	Player string
+	// Round numbers the draws from 1
+	Round     int
+	Count     int
+	Discarded []card.Card
+	Drawn     []card.Card
+}
+
+// Hand is a parsed hand history
+type Hand struct {
+	ID     string
+	Site   string
+	Game   Game
+	Limit  string
+	Table  string
+	Button int
+
+	Players []Player
+	// Hero is the player whose cards were dealt face up to the history
+	Hero    string
+	Dealt   []card.Card
+	Actions []Action
+	Draws   []Draw
+
+	// Shown maps players who showed at showdown to their five cards
+	Shown     map[string][]card.Card
+	Collected map[string]int64
+	TotalPot  int64
+	Rake      int64
+}
+
+// Player returns the seated player with the given name
+func (h *Hand) Player(name string) (Player, bool) {
+	for _, p := range h.Players {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Player{}, false
+}
+
+// HeroHand returns the hero's cards after the given number of draws, or nil
+// if the hero's cards are not known
+func (h *Hand) HeroHand(draws int) []card.Card {
+	if len(h.Dealt) == 0 {
+		return nil
+	}
+	hand := append([]card.Card(nil), h.Dealt...)
+	for _, d := range h.Draws {
+		if d.Player != h.Hero || d.Round > draws {
+			continue
+		}
+		hand = replaceCards(hand, d.Discarded, d.Drawn)
+	}
+	return hand
+}
+
+// replaceCards removes discarded from hand and appends drawn
+func replaceCards(hand, discarded, drawn []card.Card) []card.Card {
+	kept := hand[:0]
+	for _, c := range hand {
+		if !containsCard(discarded, c) {
+			kept = append(kept, c)
+		}
+	}
+	return append(kept, drawn...)
+}
+
+func containsCard(cards []card.Card, c card.Card) bool {
+	for _, x := range cards {
+		if x == c {
+			return true
+		}
+	}
+	return false
+}
+
+// ShownHand is a hand shown down and its 2-7 value
+type ShownHand struct {
+	Player string
+	Cards  []card.Card
+	Value  handrank.HandValue
+}
+
+// Showdown evaluates every shown hand in seat order and returns them with
+// the names of the players holding the best 2-7 hand
+func (h *Hand) Showdown(ht *deucelowsingle.HashTable) ([]ShownHand, []string) {
+	var shown []ShownHand
+	var hands [][]card.Card
+	for _, p := range h.Players {
+		if cards, ok := h.Shown[p.Name]; ok {
+			shown = append(shown, ShownHand{Player: p.Name, Cards: cards, Value: ht.Value(cards)})
+			hands = append(hands, cards)
+		}
+	}
+
+	var winners []string
+	if len(shown) > 0 {
+		for _, w := range ht.Showdown(hands...).Winners {
+			winners = append(winners, shown[w].Player)
+		}
+	}
+	return shown, winners
+}
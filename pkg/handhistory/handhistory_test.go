@@ -0,0 +1,185 @@
+package handhistory
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+const tripleDraw = `PokerStars Hand #212345678901:  Triple Draw 2-7 Lowball Limit ($10/$20 USD) - 2024/03/01 20:15:00 ET
+Table 'Alcyone III' 6-max Seat #1 is the button
+Seat 1: alice ($500 in chips)
+Seat 2: bob ($482.50 in chips)
+Seat 3: carol ($1,000 in chips)
+bob: posts small blind $5
+carol: posts big blind $10
+*** DEALING HANDS ***
+Dealt to alice [7s 5h 4d Kc Kd]
+alice: raises $10 to $20
+bob: calls $15
+carol: folds
+*** FIRST DRAW ***
+bob: discards 2 cards
+alice: discards 2 cards [Kc Kd]
+Dealt to alice [7s 5h 4d] [Qh 3c]
+bob: bets $10
+alice: calls $10
+*** SECOND DRAW ***
+bob: discards 1 card
+alice: discards 1 card [Qh]
+Dealt to alice [7s 5h 4d 3c] [2s]
+bob: bets $20
+alice: raises $20 to $40
+bob: calls $20
+*** THIRD DRAW ***
+bob: stands pat
+alice: stands pat
+bob: checks
+alice: bets $20
+bob: calls $20
+*** SHOW DOWN ***
+alice: shows [7s 5h 4d 3c 2s] (Lo: 7,5,4,3,2)
+bob: shows [8c 6d 4h 3h 2h] (Lo: 8,6,4,3,2)
+alice collected $187 from pot
+*** SUMMARY ***
+Total pot $190 | Rake $3
+Seat 1: alice (button) showed [7s 5h 4d 3c 2s] and won ($187)
+Seat 2: bob (small blind) showed [8c 6d 4h 3h 2h] and lost
+Seat 3: carol (big blind) folded before the Draw
+`
+
+const singleDraw = `Poker Hand #SD5551234: 2-7 Single Draw No Limit (50/100) - 2024/03/02 10:00:00
+Table 'NL 2-7 12' 6-max Seat #2 is the button
+Seat 1: Hero (10,000 in chips)
+Seat 2: villain (8,500 in chips)
+Hero: posts small blind 50
+villain: posts big blind 100
+*** DEALING HANDS ***
+Dealt to Hero [9h 8c 6s 3d 2h]
+Hero: raises 200 to 300
+villain: calls 200
+*** DRAW ***
+Hero: stands pat
+villain: discards 1 card
+Hero: bets 400
+villain: folds
+Uncalled bet (400) returned to Hero
+Hero collected 600 from pot
+*** SUMMARY ***
+Total pot 600 | Rake 0
+`
+
+func TestParseTripleDraw(t *testing.T) {
+	h, err := ParseHand(tripleDraw)
+	if err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+	if h.ID != "212345678901" || h.Site != "PokerStars" || h.Game != TripleDraw || h.Limit != "Limit" {
+		t.Errorf("Unexpected header fields: %s %s %v %s", h.ID, h.Site, h.Game, h.Limit)
+	}
+	if h.Table != "Alcyone III" || h.Button != 1 || len(h.Players) != 3 {
+		t.Errorf("Unexpected table fields: %q button %d, %d players", h.Table, h.Button, len(h.Players))
+	}
+	if bob, _ := h.Player("bob"); bob.Stack != 48250 {
+		t.Errorf("Expected bob's stack to be 48250, got %d", bob.Stack)
+	}
+	if h.TotalPot != 19000 || h.Rake != 300 || h.Collected["alice"] != 18700 {
+		t.Errorf("Unexpected pot %d, rake %d, collected %d", h.TotalPot, h.Rake, h.Collected["alice"])
+	}
+
+	if len(h.Draws) != 6 {
+		t.Fatalf("Expected 6 draws, got %d", len(h.Draws))
+	}
+	if d := h.Draws[0]; d.Player != "bob" || d.Round != 1 || d.Count != 2 || len(d.Discarded) != 0 {
+		t.Errorf("Unexpected first draw %+v", d)
+	}
+	if got := deck.FormatCards(h.HeroHand(1)); got != "7s 5h 4d Qh 3c" {
+		t.Errorf("Expected the hero to hold 7s 5h 4d Qh 3c after the first draw, got %s", got)
+	}
+
+	var raises int
+	for _, a := range h.Actions {
+		if a.Kind == Raise {
+			raises++
+			if a.Round == 2 && (a.Amount != 2000 || a.To != 4000) {
+				t.Errorf("Expected raise of 2000 to 4000, got %d to %d", a.Amount, a.To)
+			}
+		}
+	}
+	if raises != 2 {
+		t.Errorf("Expected 2 raises, got %d", raises)
+	}
+
+	shown, winners := h.Showdown(deucelowsingle.NewHashTable())
+	if len(shown) != 2 || !reflect.DeepEqual(winners, []string{"alice"}) {
+		t.Errorf("Expected alice to win a two-way showdown, got %v from %d hands", winners, len(shown))
+	}
+}
+
+func TestReaderMultipleHands(t *testing.T) {
+	r := NewReader(strings.NewReader("Some preamble\n" + tripleDraw + "\n\n" + singleDraw))
+	var ids []string
+	for {
+		h, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		ids = append(ids, h.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"212345678901", "SD5551234"}) {
+		t.Errorf("Expected both hands, got %v", ids)
+	}
+
+	h, err := ParseHand(singleDraw)
+	if err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+	if h.Site != "GGPoker" || h.Game != SingleDraw || h.Limit != "No Limit" {
+		t.Errorf("Unexpected header fields: %s %v %s", h.Site, h.Game, h.Limit)
+	}
+	if p, _ := h.Player("Hero"); p.Stack != 1000000 {
+		t.Errorf("Expected a stack of 1000000, got %d", p.Stack)
+	}
+	if got := deck.FormatCards(h.HeroHand(1)); got != "9h 8c 6s 3d 2h" {
+		t.Errorf("Expected the pat hand to be unchanged, got %s", got)
+	}
+}
+
+func TestInconsistentHands(t *testing.T) {
+	tests := []struct {
+		name    string
+		old     string
+		new     string
+		cardErr bool
+	}{
+		{"Discard not held", "discards 1 card [Qh]", "discards 1 card [Qs]", true},
+		{"Drawn card already held", "[7s 5h 4d 3c] [2s]", "[7s 5h 4d 3c] [5h]", true},
+		{"Card shown twice", "bob: shows [8c", "bob: shows [7s", true},
+		{"Shown hand not held", "alice: shows [7s 5h 4d 3c 2s]", "alice: shows [7s 5h 4d 3c 2c]", true},
+		{"Wrong draw count", "[7s 5h 4d] [Qh 3c]", "[7s 5h 4d] [Qh]", false},
+		{"Too many draws", "*** THIRD DRAW ***", "*** THIRD DRAW ***\nbob: stands pat", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseHand(strings.Replace(tripleDraw, tt.old, tt.new, 1))
+			var cardErr *CardError
+			if err == nil || errors.As(err, &cardErr) != tt.cardErr {
+				t.Errorf("Expected a card error %v, got %v", tt.cardErr, err)
+			}
+		})
+	}
+
+	_, err := ParseHand(strings.Replace(tripleDraw, "Triple Draw 2-7 Lowball", "Badugi", 1))
+	var gameErr *UnsupportedGameError
+	if !errors.As(err, &gameErr) {
+		t.Errorf("Expected UnsupportedGameError, got %v", err)
+	}
+}
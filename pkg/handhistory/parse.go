@@ -0,0 +1,297 @@
+package handhistory
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+)
+
+const amount = `[$€£]?([\d,]+(?:\.\d+)?)`
+
+var (
+	headerRe    = regexp.MustCompile(`^(PokerStars|Poker) Hand #(\S+?):\s+(.*)$`)
+	tableRe     = regexp.MustCompile(`^Table '([^']*)'.*Seat #(\d+) is the button`)
+	seatRe      = regexp.MustCompile(`^Seat (\d+): (.+?) \(` + amount + ` in chips`)
+	streetRe    = regexp.MustCompile(`^\*\*\* (.+?) \*\*\*`)
+	dealtRe     = regexp.MustCompile(`^Dealt to (.+?) \[([^\]]*)\](?: \[([^\]]*)\])?`)
+	blindRe     = regexp.MustCompile(`^(.+?): posts (small blind|big blind|the ante) ` + amount)
+	foldRe      = regexp.MustCompile(`^(.+?): folds`)
+	checkRe     = regexp.MustCompile(`^(.+?): checks`)
+	callRe      = regexp.MustCompile(`^(.+?): (calls|bets) ` + amount)
+	raiseRe     = regexp.MustCompile(`^(.+?): raises ` + amount + ` to ` + amount)
+	discardRe   = regexp.MustCompile(`^(.+?): discards (\d+) cards?(?: \[([^\]]*)\])?`)
+	patRe       = regexp.MustCompile(`^(.+?): stands pat`)
+	showRe      = regexp.MustCompile(`^(.+?): shows \[([^\]]*)\]`)
+	uncalledRe  = regexp.MustCompile(`^Uncalled bet \(` + amount + `\) returned to (.+)$`)
+	collectedRe = regexp.MustCompile(`^(.+?) collected ` + amount + ` from`)
+	totalRe     = regexp.MustCompile(`^Total pot ` + amount + `.*\| Rake ` + amount)
+)
+
+// Reader reads consecutive hands from a text stream of hand histories
+type Reader struct {
+	scanner *bufio.Scanner
+	line    int
+	pending string
+	started bool
+}
+
+// NewReader returns a Reader over r
+func NewReader(r io.Reader) *Reader {
+	return &Reader{scanner: bufio.NewScanner(r)}
+}
+
+// Next parses and validates the next hand. It returns io.EOF when the stream
+// is exhausted. A hand that fails to parse or validate is skipped, so the
+// caller may keep calling Next after an error.
+func (r *Reader) Next() (*Hand, error) {
+	var lines []string
+	first := 0
+	if r.started {
+		lines, first = append(lines, r.pending), r.line
+	}
+	for r.scanner.Scan() {
+		r.line++
+		text := strings.TrimPrefix(strings.TrimSpace(r.scanner.Text()), "\ufeff")
+		if headerRe.MatchString(text) {
+			if len(lines) > 0 {
+				r.pending = text
+				return parseLines(lines, first)
+			}
+			r.started, first = true, r.line
+		}
+		if r.started {
+			lines = append(lines, text)
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	r.started = false
+	if len(lines) == 0 {
+		return nil, io.EOF
+	}
+	return parseLines(lines, first)
+}
+
+// ParseHand parses and validates a single hand history
+func ParseHand(text string) (*Hand, error) {
+	h, err := NewReader(strings.NewReader(text)).Next()
+	if err == io.EOF {
+		return nil, &ParseError{Line: 1, Reason: "no hand header found"}
+	}
+	return h, err
+}
+
+// parser holds the state of the hand being parsed
+type parser struct {
+	hand    *Hand
+	round   int
+	summary bool
+}
+
+// parseLines parses one hand whose header is on line first of the stream
+func parseLines(lines []string, first int) (*Hand, error) {
+	p := &parser{hand: &Hand{
+		Shown:     make(map[string][]card.Card),
+		Collected: make(map[string]int64),
+	}}
+	for i, text := range lines {
+		if text == "" {
+			continue
+		}
+		if err := p.parseLine(text); err != nil {
+			var gameErr *UnsupportedGameError
+			if errors.As(err, &gameErr) {
+				return nil, err
+			}
+			return nil, &ParseError{HandID: p.hand.ID, Line: first + i, Text: text, Reason: err.Error()}
+		}
+	}
+	if err := p.hand.Validate(); err != nil {
+		return nil, err
+	}
+	return p.hand, nil
+}
+
+func (p *parser) parseLine(text string) error {
+	h := p.hand
+	if m := headerRe.FindStringSubmatch(text); m != nil {
+		h.ID, h.Site = m[2], m[1]
+		if h.Site == "Poker" {
+			h.Site = "GGPoker"
+		}
+		return p.parseGame(m[3])
+	}
+	if m := streetRe.FindStringSubmatch(text); m != nil {
+		return p.parseStreet(m[1])
+	}
+	if p.summary {
+		if m := totalRe.FindStringSubmatch(text); m != nil {
+			h.TotalPot, h.Rake = parseAmount(m[1]), parseAmount(m[2])
+		}
+		return nil
+	}
+
+	if m := tableRe.FindStringSubmatch(text); m != nil {
+		h.Table = m[1]
+		h.Button, _ = strconv.Atoi(m[2])
+		return nil
+	}
+	if m := seatRe.FindStringSubmatch(text); m != nil {
+		seat, _ := strconv.Atoi(m[1])
+		h.Players = append(h.Players, Player{Seat: seat, Name: m[2], Stack: parseAmount(m[3])})
+		return nil
+	}
+	if m := dealtRe.FindStringSubmatch(text); m != nil {
+		return p.parseDealt(m[1], m[2], m[3])
+	}
+	if m := blindRe.FindStringSubmatch(text); m != nil {
+		kind := map[string]ActionKind{
+			"small blind": PostSmallBlind, "big blind": PostBigBlind, "the ante": PostAnte,
+		}[m[2]]
+		p.action(m[1], kind, parseAmount(m[3]), 0, text)
+		return nil
+	}
+	if m := raiseRe.FindStringSubmatch(text); m != nil {
+		p.action(m[1], Raise, parseAmount(m[2]), parseAmount(m[3]), text)
+		return nil
+	}
+	if m := callRe.FindStringSubmatch(text); m != nil {
+		kind := Call
+		if m[2] == "bets" {
+			kind = Bet
+		}
+		p.action(m[1], kind, parseAmount(m[3]), 0, text)
+		return nil
+	}
+	if m := checkRe.FindStringSubmatch(text); m != nil {
+		p.action(m[1], Check, 0, 0, text)
+		return nil
+	}
+	if m := foldRe.FindStringSubmatch(text); m != nil {
+		p.action(m[1], Fold, 0, 0, text)
+		return nil
+	}
+	if m := discardRe.FindStringSubmatch(text); m != nil {
+		count, _ := strconv.Atoi(m[2])
+		cards, err := deck.ParseCards(m[3])
+		if err != nil {
+			return err
+		}
+		h.Draws = append(h.Draws, Draw{Player: m[1], Round: p.round, Count: count, Discarded: cards})
+		return nil
+	}
+	if m := patRe.FindStringSubmatch(text); m != nil {
+		h.Draws = append(h.Draws, Draw{Player: m[1], Round: p.round})
+		return nil
+	}
+	if m := showRe.FindStringSubmatch(text); m != nil {
+		cards, err := deck.ParseCards(m[2])
+		if err != nil {
+			return err
+		}
+		h.Shown[m[1]] = cards
+		return nil
+	}
+	if m := uncalledRe.FindStringSubmatch(text); m != nil {
+		p.action(m[2], UncalledBet, parseAmount(m[1]), 0, text)
+		return nil
+	}
+	if m := collectedRe.FindStringSubmatch(text); m != nil {
+		p.action(m[1], Collect, parseAmount(m[2]), 0, text)
+		h.Collected[m[1]] += parseAmount(m[2])
+		return nil
+	}
+	// Chat, table announcements and other lines carry nothing we track
+	return nil
+}
+
+// parseGame reads the game and limit from the rest of the header line
+func (p *parser) parseGame(desc string) error {
+	switch {
+	case strings.Contains(desc, "Triple Draw"):
+		p.hand.Game = TripleDraw
+	case strings.Contains(desc, "Single Draw"), strings.Contains(desc, "5 Card Draw 2-7"):
+		p.hand.Game = SingleDraw
+	default:
+		return &UnsupportedGameError{Description: desc}
+	}
+	for _, limit := range []string{"No Limit", "Pot Limit", "Limit"} {
+		if strings.Contains(desc, limit) {
+			p.hand.Limit = limit
+			break
+		}
+	}
+	return nil
+}
+
+// parseStreet moves to the betting round or section a street marker opens
+func (p *parser) parseStreet(name string) error {
+	switch name {
+	case "DRAW", "FIRST DRAW":
+		p.round = 1
+	case "SECOND DRAW":
+		p.round = 2
+	case "THIRD DRAW":
+		p.round = 3
+	case "SUMMARY":
+		p.summary = true
+	}
+	if p.round > p.hand.Game.Draws() {
+		return fmt.Errorf("too many draws for %v", p.hand.Game)
+	}
+	return nil
+}
+
+// parseDealt records the hero's starting hand, or the cards drawn in a draw
+// round. Draw rounds list the kept cards and then the new cards.
+func (p *parser) parseDealt(player, first, second string) error {
+	h := p.hand
+	if p.round == 0 {
+		cards, err := deck.ParseCards(first)
+		if err != nil {
+			return err
+		}
+		h.Hero, h.Dealt = player, cards
+		return nil
+	}
+	drawn, err := deck.ParseCards(second)
+	if err != nil {
+		return err
+	}
+	for i := len(h.Draws) - 1; i >= 0; i-- {
+		if d := &h.Draws[i]; d.Player == player && d.Round == p.round {
+			d.Drawn = drawn
+			return nil
+		}
+	}
+	return fmt.Errorf("%s was dealt cards without discarding", player)
+}
+
+func (p *parser) action(player string, kind ActionKind, amount, to int64, text string) {
+	p.hand.Actions = append(p.hand.Actions, Action{
+		Player: player,
+		Round:  p.round,
+		Kind:   kind,
+		Amount: amount,
+		To:     to,
+		AllIn:  strings.HasSuffix(text, "all-in"),
+	})
+}
+
+// parseAmount reads a number such as "1,500" or "10.5" in hundredths
+func parseAmount(s string) int64 {
+	s = strings.ReplaceAll(s, ",", "")
+	whole, frac, _ := strings.Cut(s, ".")
+	n, _ := strconv.ParseInt(whole, 10, 64)
+	frac = (frac + "00")[:2]
+	cents, _ := strconv.ParseInt(frac, 10, 64)
+	return n*100 + cents
+}
@@ -0,0 +1,139 @@
+package handhistory
+
+import (
+	"fmt"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+)
+
+const handSize = 5
+
+// Validate checks that the cards in a hand are consistent: every player
+// named is seated, the hero holds five distinct cards after each draw and
+// only discards cards they hold, each draw replaces as many cards as were
+// discarded, and no card is shown by two players. A player's shown hand must
+// match what they are known to have drawn to.
+func (h *Hand) Validate() error {
+	if h.ID == "" {
+		return &ConsistencyError{Reason: "missing hand header"}
+	}
+	for _, a := range h.Actions {
+		if _, ok := h.Player(a.Player); !ok {
+			return &ConsistencyError{HandID: h.ID, Reason: fmt.Sprintf("%s acts but is not seated", a.Player)}
+		}
+	}
+
+	draws := make(map[string]int)
+	for _, d := range h.Draws {
+		if _, ok := h.Player(d.Player); !ok {
+			return &ConsistencyError{HandID: h.ID, Reason: fmt.Sprintf("%s draws but is not seated", d.Player)}
+		}
+		if d.Count < 0 || d.Count > handSize {
+			return &ConsistencyError{HandID: h.ID, Reason: fmt.Sprintf("%s discards %d cards", d.Player, d.Count)}
+		}
+		if len(d.Discarded) > 0 && len(d.Discarded) != d.Count {
+			return &ConsistencyError{HandID: h.ID,
+				Reason: fmt.Sprintf("%s discards %d cards but %d are listed", d.Player, d.Count, len(d.Discarded))}
+		}
+		if len(d.Drawn) > 0 && len(d.Drawn) != d.Count {
+			return &ConsistencyError{HandID: h.ID,
+				Reason: fmt.Sprintf("%s discards %d cards but draws %d", d.Player, d.Count, len(d.Drawn))}
+		}
+		draws[d.Player]++
+		if draws[d.Player] > h.Game.Draws() {
+			return &ConsistencyError{HandID: h.ID,
+				Reason: fmt.Sprintf("%s draws more than %d times", d.Player, h.Game.Draws())}
+		}
+	}
+
+	if h.Hero != "" {
+		if err := h.validateHero(); err != nil {
+			return err
+		}
+	}
+
+	owner := make(map[card.Card]string)
+	for _, p := range h.Players {
+		cards, ok := h.Shown[p.Name]
+		if !ok {
+			continue
+		}
+		if len(cards) != handSize {
+			return &ConsistencyError{HandID: h.ID, Reason: fmt.Sprintf("%s shows %d cards", p.Name, len(cards))}
+		}
+		for _, c := range cards {
+			if !deck.Standard().Contains(c) {
+				return &CardError{HandID: h.ID, Player: p.Name, Card: c, Reason: "shows a card not in the deck:"}
+			}
+			if other, ok := owner[c]; ok {
+				reason := "shows a duplicate:"
+				if other != p.Name {
+					reason = "shows a card also shown by " + other + ":"
+				}
+				return &CardError{HandID: h.ID, Player: p.Name, Card: c, Reason: reason}
+			}
+			owner[c] = p.Name
+		}
+	}
+	for name := range h.Shown {
+		if _, ok := h.Player(name); !ok {
+			return &ConsistencyError{HandID: h.ID, Reason: fmt.Sprintf("%s shows but is not seated", name)}
+		}
+	}
+	return nil
+}
+
+// validateHero replays the hero's draws, checking each discard against the
+// cards held and the final hand against any shown hand
+func (h *Hand) validateHero() error {
+	if len(h.Dealt) != handSize {
+		return &ConsistencyError{HandID: h.ID, Reason: fmt.Sprintf("%s is dealt %d cards", h.Hero, len(h.Dealt))}
+	}
+	hand := append([]card.Card(nil), h.Dealt...)
+	if err := h.checkDistinct(hand); err != nil {
+		return err
+	}
+	for _, d := range h.Draws {
+		if d.Player != h.Hero {
+			continue
+		}
+		if d.Count > 0 && (len(d.Discarded) != d.Count || len(d.Drawn) != d.Count) {
+			return &ConsistencyError{HandID: h.ID,
+				Reason: fmt.Sprintf("%s's cards for draw %d are not listed", h.Hero, d.Round)}
+		}
+		for _, c := range d.Discarded {
+			if !containsCard(hand, c) {
+				return &CardError{HandID: h.ID, Player: h.Hero, Card: c, Reason: "discards a card not held:"}
+			}
+		}
+		hand = replaceCards(hand, d.Discarded, d.Drawn)
+		if err := h.checkDistinct(hand); err != nil {
+			return err
+		}
+	}
+
+	if shown, ok := h.Shown[h.Hero]; ok {
+		for _, c := range shown {
+			if !containsCard(hand, c) {
+				return &CardError{HandID: h.ID, Player: h.Hero, Card: c, Reason: "shows a card not held:"}
+			}
+		}
+	}
+	return nil
+}
+
+// checkDistinct reports a card the hero holds twice or that is not in the deck
+func (h *Hand) checkDistinct(hand []card.Card) error {
+	seen := make(map[card.Card]bool, len(hand))
+	for _, c := range hand {
+		if !deck.Standard().Contains(c) {
+			return &CardError{HandID: h.ID, Player: h.Hero, Card: c, Reason: "holds a card not in the deck:"}
+		}
+		if seen[c] {
+			return &CardError{HandID: h.ID, Player: h.Hero, Card: c, Reason: "holds a duplicate:"}
+		}
+		seen[c] = true
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package handrank
+
+import (
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+)
+
+// HandValue is a variant-specific hand strength. Whether lower or higher
+// values are better depends on the variant's GameRules.
+type HandValue uint64
+
+// GameRules describes the hands a variant evaluates
+type GameRules struct {
+	MaxCards  int
+	MinCards  int
+	UseSuits  bool
+	HandSize  int
+	IsLowball bool
+}
+
+// Better reports whether a is a strictly better hand than b under the
+// rules' orientation: lower values win in lowball games, higher otherwise
+func (r GameRules) Better(a, b HandValue) bool {
+	if r.IsLowball {
+		return a < b
+	}
+	return a > b
+}
+
+// Evaluator scores a hand of cards for a single variant
+type Evaluator interface {
+	Value(cards []card.Card) HandValue
+	Rules() GameRules
+}
+
+// DeckProvider is implemented by evaluators whose variant is played with a
+// deck other than the standard 52 cards
+type DeckProvider interface {
+	Deck() deck.Deck
+}
+
+// rankMultisetBinomials holds C(n, k) for EncodeRankCounts
+var rankMultisetBinomials = func() [18][6]uint32 {
+	var c [18][6]uint32
+	for n := range c {
+		c[n][0] = 1
+		for k := 1; k < len(c[n]) && k <= n; k++ {
+			c[n][k] = c[n-1][k-1] + c[n-1][k]
+		}
+	}
+	return c
+}()
+
+// RankMultisets returns the number of distinct multisets of size cards drawn
+// from 13 ranks, C(12+size, size). It bounds the indexes EncodeRankCounts
+// returns for that size.
+func RankMultisets(size int) int {
+	return int(rankMultisetBinomials[12+size][size])
+}
+
+// EncodeRankCounts converts per-rank card counts to a dense index below
+// RankMultisets of the total count, which must be at most 5
+func EncodeRankCounts(counts []uint8) uint32 {
+	// Each rank multiset r0 <= r1 <= ... maps to the strictly increasing
+	// sequence ri+i, which the combinatorial number system indexes densely.
+	var index uint32
+	i := 0
+	for rank, count := range counts {
+		for j := uint8(0); j < count; j++ {
+			index += rankMultisetBinomials[rank+i][i+1]
+			i++
+		}
+	}
+	return index
+}
@@ -0,0 +1,137 @@
+// Package reference ranks five-card hands by explicit rules, without lookup
+// tables, so that tests can check the table-driven evaluators against it.
+package reference
+
+import (
+	"sort"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+const handSize = 5
+
+// Category is the class of a hand, from high card up to straight flush
+type Category int
+
+const (
+	HighCard Category = iota
+	Pair
+	TwoPair
+	Trips
+	Straight
+	Flush
+	FullHouse
+	Quads
+	StraightFlush
+)
+
+// Key describes a hand by its category and the ranks that break ties within
+// it, most significant first. Ranks run from 1 for a deuce to 13 for an ace,
+// which is 0 when it plays low in a wheel.
+type Key struct {
+	Category Category
+	Ranks    [handSize]int
+}
+
+// High ranks a five-card high hand, in which A-2-3-4-5 is the lowest straight
+func High(cards []card.Card) Key {
+	return evaluate(cards, true)
+}
+
+// DeuceSeven ranks a five-card 2-7 hand, in which the ace only plays high
+// and A-2-3-4-5 is not a straight. The better 2-7 hand has the lower key.
+func DeuceSeven(cards []card.Card) Key {
+	return evaluate(cards, false)
+}
+
+// Compare returns 1 if a has the higher category or, within a category, the
+// higher ranks, -1 if b does, and 0 if they are equal
+func Compare(a, b Key) int {
+	if a.Category != b.Category {
+		if a.Category > b.Category {
+			return 1
+		}
+		return -1
+	}
+	for i := range a.Ranks {
+		if a.Ranks[i] != b.Ranks[i] {
+			if a.Ranks[i] > b.Ranks[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
+
+func evaluate(cards []card.Card, wheel bool) Key {
+	counts := make(map[int]int)
+	ordered := make([]int, 0, handSize)
+	for _, c := range cards {
+		r := rank(c)
+		counts[r]++
+		ordered = append(ordered, r)
+	}
+	// Order ranks by group size, then by rank, both descending
+	sort.Slice(ordered, func(i, j int) bool {
+		if counts[ordered[i]] != counts[ordered[j]] {
+			return counts[ordered[i]] > counts[ordered[j]]
+		}
+		return ordered[i] > ordered[j]
+	})
+
+	flush := true
+	for _, c := range cards[1:] {
+		if c.Suit() != cards[0].Suit() {
+			flush = false
+		}
+	}
+
+	straight := false
+	if len(counts) == handSize {
+		if ordered[0]-ordered[handSize-1] == handSize-1 {
+			straight = true
+		} else if wheel && ordered[0] == 13 && ordered[1] == 4 {
+			straight = true
+			ordered = []int{4, 3, 2, 1, 0}
+		}
+	}
+
+	groups := make([]int, 0, len(counts))
+	for _, n := range counts {
+		groups = append(groups, n)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(groups)))
+
+	key := Key{}
+	copy(key.Ranks[:], ordered)
+	switch {
+	case straight && flush:
+		key.Category = StraightFlush
+	case groups[0] == 4:
+		key.Category = Quads
+	case groups[0] == 3 && groups[1] == 2:
+		key.Category = FullHouse
+	case flush:
+		key.Category = Flush
+	case straight:
+		key.Category = Straight
+	case groups[0] == 3:
+		key.Category = Trips
+	case groups[0] == 2 && groups[1] == 2:
+		key.Category = TwoPair
+	case groups[0] == 2:
+		key.Category = Pair
+	default:
+		key.Category = HighCard
+	}
+	return key
+}
+
+// rank returns a card's rank with the ace high
+func rank(c card.Card) int {
+	if c.Rank() == card.Ace {
+		return 13
+	}
+	return int(c.Rank())
+}
@@ -0,0 +1,77 @@
+package handrank
+
+// SplitPot adds an equal share of amount to payouts for each winner.
+// Winners are indexes into payouts listed in seat order starting left of the
+// button; chips that do not divide evenly go one each to the earliest winners.
+func SplitPot(payouts []int, amount int, winners []int) {
+	if len(winners) == 0 || amount <= 0 {
+		return
+	}
+	share, odd := amount/len(winners), amount%len(winners)
+	for i, w := range winners {
+		payouts[w] += share
+		if i < odd {
+			payouts[w]++
+		}
+	}
+}
+
+// HiLoValue is the result of evaluating a hand in a high-low split game.
+// Higher High values are better; Low is an A-5 value where lower is better,
+// and is only meaningful when HasLow is set.
+type HiLoValue struct {
+	High   HandValue
+	Low    HandValue
+	HasLow bool
+}
+
+// HiLoShowdown splits a hi-lo pot among players still in the hand. Values are in
+// seat order starting left of the button. Half the pot goes to the best high
+// hand and half to the best qualifying low, with the odd chip going to the
+// high half; if no low qualifies the high hand scoops. Tied hands share a
+// half, which is how quartering arises, and chips that do not split evenly go
+// to the earliest tied seat. It returns each player's payout.
+func HiLoShowdown(pot int, values []HiLoValue) []int {
+	payouts := make([]int, len(values))
+	if len(values) == 0 {
+		return payouts
+	}
+
+	highWinners, lowWinners := HiLoWinners(values)
+	if len(lowWinners) == 0 {
+		SplitPot(payouts, pot, highWinners)
+		return payouts
+	}
+
+	lowHalf := pot / 2
+	SplitPot(payouts, pot-lowHalf, highWinners)
+	SplitPot(payouts, lowHalf, lowWinners)
+	return payouts
+}
+
+// HiLoWinners returns the seats holding the best high hand and the seats
+// holding the best qualifying low, which is empty if no low qualifies
+func HiLoWinners(values []HiLoValue) (highWinners, lowWinners []int) {
+	for i, v := range values {
+		switch {
+		case len(highWinners) == 0 || v.High > values[highWinners[0]].High:
+			highWinners = []int{i}
+		case v.High == values[highWinners[0]].High:
+			highWinners = append(highWinners, i)
+		}
+	}
+
+	for i, v := range values {
+		if !v.HasLow {
+			continue
+		}
+		switch {
+		case len(lowWinners) == 0 || v.Low < values[lowWinners[0]].Low:
+			lowWinners = []int{i}
+		case v.Low == values[lowWinners[0]].Low:
+			lowWinners = append(lowWinners, i)
+		}
+	}
+
+	return highWinners, lowWinners
+}
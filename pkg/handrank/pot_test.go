@@ -0,0 +1,92 @@
+package handrank
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHiLoShowdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		pot    int
+		values []HiLoValue
+		want   []int
+	}{
+		{
+			name:   "Scoop without a qualifying low",
+			pot:    101,
+			values: []HiLoValue{{High: 500}, {High: 400}},
+			want:   []int{101, 0},
+		},
+		{
+			name:   "Even split with odd chip to high",
+			pot:    101,
+			values: []HiLoValue{{High: 500}, {High: 400, Low: 10, HasLow: true}},
+			want:   []int{51, 50},
+		},
+		{
+			name:   "Scoop high and low",
+			pot:    100,
+			values: []HiLoValue{{High: 500, Low: 10, HasLow: true}, {High: 400, Low: 20, HasLow: true}},
+			want:   []int{100, 0},
+		},
+		{
+			name: "Quartered",
+			pot:  100,
+			values: []HiLoValue{
+				{High: 500, Low: 10, HasLow: true},
+				{High: 300, Low: 10, HasLow: true},
+				{High: 400},
+			},
+			want: []int{75, 25, 0},
+		},
+		{
+			name: "Odd chips to earliest seat",
+			pot:  103,
+			values: []HiLoValue{
+				{High: 300, Low: 10, HasLow: true},
+				{High: 500},
+				{High: 500},
+			},
+			want: []int{51, 26, 26},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HiLoShowdown(tt.pot, tt.values); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HiLoShowdown(%d) = %v, want %v", tt.pot, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShowdown(t *testing.T) {
+	low := GameRules{IsLowball: true}
+	high := GameRules{}
+	if low.Compare(10, 20) != 1 || low.Compare(20, 10) != -1 || low.Compare(10, 10) != 0 {
+		t.Errorf("Expected lower values to win under lowball rules")
+	}
+	if high.Compare(10, 20) != -1 || high.Compare(20, 10) != 1 {
+		t.Errorf("Expected higher values to win under high rules")
+	}
+
+	s := low.Showdown(30, 10, 20, 10)
+	if !reflect.DeepEqual(s.Winners, []int{1, 3}) || !s.Tie() {
+		t.Errorf("Expected seats 1 and 3 to tie, got %v", s.Winners)
+	}
+	if !reflect.DeepEqual(s.Shares, []float64{0, 0.5, 0, 0.5}) {
+		t.Errorf("Expected half shares for the tied seats, got %v", s.Shares)
+	}
+	if got := s.Payouts(101); !reflect.DeepEqual(got, []int{0, 51, 0, 50}) {
+		t.Errorf("Expected the odd chip to the earlier winner, got %v", got)
+	}
+
+	s = high.Showdown(30, 10, 20)
+	if !reflect.DeepEqual(s.Winners, []int{0}) || s.Tie() {
+		t.Errorf("Expected seat 0 to win outright, got %v", s.Winners)
+	}
+	if s := low.Showdown(); len(s.Winners) != 0 {
+		t.Errorf("Expected no winners without hands, got %v", s.Winners)
+	}
+}
@@ -0,0 +1,76 @@
+package handrank
+
+import "github.com/dgunzy/card/pkg/card"
+
+// Compare returns 1 if a is the better hand under the rules' orientation, -1
+// if b is, and 0 if they tie
+func (r GameRules) Compare(a, b HandValue) int {
+	switch {
+	case r.Better(a, b):
+		return 1
+	case r.Better(b, a):
+		return -1
+	}
+	return 0
+}
+
+// ShowdownResult is the outcome of a showdown between hands in seat order
+type ShowdownResult struct {
+	Values []HandValue
+	// Winners lists the seats holding the best hand, in seat order
+	Winners []int
+	// Shares holds each seat's share of the pot, which is 1/len(Winners) for
+	// a winner and 0 otherwise
+	Shares []float64
+}
+
+// Tie reports whether more than one seat shares the best hand
+func (s ShowdownResult) Tie() bool {
+	return len(s.Winners) > 1
+}
+
+// Payouts splits a pot of whole chips among the winners with SplitPot, so
+// odd chips go to the earliest winners in seat order
+func (s ShowdownResult) Payouts(pot int) []int {
+	payouts := make([]int, len(s.Values))
+	SplitPot(payouts, pot, s.Winners)
+	return payouts
+}
+
+// Showdown resolves a showdown between hand values in seat order
+func (r GameRules) Showdown(values ...HandValue) ShowdownResult {
+	s := ShowdownResult{Values: values, Shares: make([]float64, len(values))}
+	for i, v := range values {
+		switch {
+		case len(s.Winners) == 0 || r.Better(v, values[s.Winners[0]]):
+			s.Winners = []int{i}
+		case v == values[s.Winners[0]]:
+			s.Winners = append(s.Winners, i)
+		}
+	}
+	for _, w := range s.Winners {
+		s.Shares[w] = 1 / float64(len(s.Winners))
+	}
+	return s
+}
+
+// Comparer is implemented by evaluators that resolve hands of cards directly
+type Comparer interface {
+	Compare(a, b []card.Card) int
+	Showdown(hands ...[]card.Card) ShowdownResult
+}
+
+// Compare evaluates two hands with e and compares them under its rules
+func Compare(e Evaluator, a, b []card.Card) int {
+	return e.Rules().Compare(e.Value(a), e.Value(b))
+}
+
+// Showdown evaluates hands in seat order with e and resolves the showdown
+// under its rules
+func Showdown(e Evaluator, hands ...[]card.Card) ShowdownResult {
+	values := make([]HandValue, len(hands))
+	for i, h := range hands {
+		values[i] = e.Value(h)
+	}
+	return e.Rules().Showdown(values...)
+}
@@ -0,0 +1,143 @@
+package badugi
+
+import (
+	"math/bits"
+	"sort"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+)
+
+const (
+	// CategorySize is the value span of hands with the same number of
+	// playing cards
+	CategorySize = uint64(1000000)
+
+	handSize = 4
+	maxCards = 5
+	deckSize = 52
+)
+
+// Evaluator scores badugi hands: the largest subset of cards with no two of
+// the same rank or suit plays, aces are low unless the evaluator plays them
+// high, and among hands with the same number of playing cards the lowest top
+// card wins. Lower values are better and the max value is returned for
+// invalid hands.
+type Evaluator struct {
+	// subsets lists, for each hand size, the index masks of every subset
+	// small enough to play, largest subsets first
+	subsets [maxCards + 1][]uint8
+	// aceHigh ranks the ace above the king, as in badeucey
+	aceHigh bool
+}
+
+// NewEvaluator builds the subset lists for four- and five-card hands
+func NewEvaluator() *Evaluator {
+	return newEvaluator(false)
+}
+
+// NewAceHighEvaluator builds an evaluator in which the ace is the highest
+// card, so 5-4-3-2 is the best badugi and any four-card badugi with an ace is
+// the worst
+func NewAceHighEvaluator() *Evaluator {
+	return newEvaluator(true)
+}
+
+func newEvaluator(aceHigh bool) *Evaluator {
+	e := &Evaluator{aceHigh: aceHigh}
+	for n := handSize; n <= maxCards; n++ {
+		for mask := uint8(1); mask < 1<<n; mask++ {
+			if bits.OnesCount8(mask) <= handSize {
+				e.subsets[n] = append(e.subsets[n], mask)
+			}
+		}
+		sort.SliceStable(e.subsets[n], func(i, j int) bool {
+			return bits.OnesCount8(e.subsets[n][i]) > bits.OnesCount8(e.subsets[n][j])
+		})
+	}
+	return e
+}
+
+// Rules describes badugi hands, which play four cards and are dealt four, or
+// five in badeucey and badacey
+func (e *Evaluator) Rules() handrank.GameRules {
+	return handrank.GameRules{
+		MaxCards:  maxCards,
+		MinCards:  handSize,
+		UseSuits:  true,
+		HandSize:  handSize,
+		IsLowball: true,
+	}
+}
+
+// Value returns the best badugi value from four or five cards
+func (e *Evaluator) Value(cards []card.Card) handrank.HandValue {
+	best := handrank.HandValue(^uint64(0))
+	if len(cards) < handSize || len(cards) > maxCards {
+		return best
+	}
+	for _, c := range cards {
+		if int(c) >= deckSize {
+			return best
+		}
+	}
+
+	size := 0
+	for _, mask := range e.subsets[len(cards)] {
+		n := bits.OnesCount8(mask)
+		if n < size {
+			// Subsets are ordered largest first, so once a badugi of some
+			// size is found no smaller subset can beat it
+			break
+		}
+		var rankBits, suitBits uint16
+		valid := true
+		for i := range cards {
+			if mask&(1<<i) == 0 {
+				continue
+			}
+			r, s := uint16(1)<<cards[i].Rank(), uint16(1)<<cards[i].Suit()
+			if rankBits&r != 0 || suitBits&s != 0 {
+				valid = false
+				break
+			}
+			rankBits |= r
+			suitBits |= s
+		}
+		if !valid {
+			continue
+		}
+		size = n
+		if v := subsetValue(n, e.digits(rankBits)); v < best {
+			best = v
+		}
+	}
+	return best
+}
+
+// Size returns how many cards play in a hand with value v
+func Size(v handrank.HandValue) int {
+	return handSize - int(uint64(v)/CategorySize)
+}
+
+// digits maps a set of ranks to the digits 1-13 they score as, lowest card
+// first. Digits start at 1 so that the lowest card still counts.
+func (e *Evaluator) digits(rankBits uint16) uint16 {
+	if !e.aceHigh {
+		return rankBits << 1
+	}
+	// The deuce is already bit 1; move the ace from bit 0 above the king
+	return rankBits&^1 | (rankBits&1)<<13
+}
+
+// subsetValue scores n playing cards of distinct digits, ranking fewer cards
+// in bands above and then ordering by the highest card down
+func subsetValue(n int, digitBits uint16) handrank.HandValue {
+	var value uint64
+	for d := 13; d >= 1; d-- {
+		if digitBits&(1<<d) != 0 {
+			value = value*14 + uint64(d)
+		}
+	}
+	return handrank.HandValue(uint64(handSize-n)*CategorySize + value)
+}
@@ -0,0 +1,177 @@
+package badugi
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+)
+
+type cardSpec struct {
+	rank card.Rank
+	suit card.Suit
+}
+
+func makeHand(specs ...cardSpec) []card.Card {
+	cards := make([]card.Card, len(specs))
+	for i, spec := range specs {
+		cards[i] = card.NewCard(spec.suit, spec.rank)
+	}
+	return cards
+}
+
+// referenceValue finds the best badugi by comparing every playable subset
+// by size and then by its cards from the highest down
+func referenceValue(cards []card.Card) (int, []int) {
+	bestSize, bestRanks := 0, []int(nil)
+	for mask := 1; mask < 1<<len(cards); mask++ {
+		ranks := make([]int, 0, 4)
+		rankSeen, suitSeen := map[card.Rank]bool{}, map[card.Suit]bool{}
+		ok := true
+		for i, c := range cards {
+			if mask&(1<<i) == 0 {
+				continue
+			}
+			if rankSeen[c.Rank()] || suitSeen[c.Suit()] {
+				ok = false
+				break
+			}
+			rankSeen[c.Rank()], suitSeen[c.Suit()] = true, true
+			ranks = append(ranks, int(c.Rank()))
+		}
+		if !ok || len(ranks) < bestSize {
+			continue
+		}
+		for i := 0; i < len(ranks); i++ {
+			for j := i + 1; j < len(ranks); j++ {
+				if ranks[j] > ranks[i] {
+					ranks[i], ranks[j] = ranks[j], ranks[i]
+				}
+			}
+		}
+		if len(ranks) > bestSize || lowerRanks(ranks, bestRanks) {
+			bestSize, bestRanks = len(ranks), ranks
+		}
+	}
+	return bestSize, bestRanks
+}
+
+func lowerRanks(a, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func TestBadugiRankings(t *testing.T) {
+	e := NewEvaluator()
+
+	// Best to worst
+	hands := []struct {
+		cards []card.Card
+		desc  string
+	}{
+		{makeHand(cardSpec{card.Ace, card.Spades}, cardSpec{card.Two, card.Hearts},
+			cardSpec{card.Three, card.Diamonds}, cardSpec{card.Four, card.Clubs}), "Four-card 4-3-2-A"},
+		{makeHand(cardSpec{card.Ace, card.Spades}, cardSpec{card.Two, card.Hearts},
+			cardSpec{card.Three, card.Diamonds}, cardSpec{card.King, card.Clubs}), "Four-card K-3-2-A"},
+		{makeHand(cardSpec{card.Ace, card.Spades}, cardSpec{card.Two, card.Hearts},
+			cardSpec{card.Three, card.Diamonds}, cardSpec{card.Four, card.Diamonds}), "Three-card 3-2-A"},
+		{makeHand(cardSpec{card.Ace, card.Spades}, cardSpec{card.Ace, card.Hearts},
+			cardSpec{card.Two, card.Diamonds}, cardSpec{card.Four, card.Hearts}), "Three-card 4-2-A with a paired ace"},
+		{makeHand(cardSpec{card.Ace, card.Spades}, cardSpec{card.Two, card.Spades},
+			cardSpec{card.Three, card.Spades}, cardSpec{card.Four, card.Hearts}), "Two-card 4-A"},
+		{makeHand(cardSpec{card.Ace, card.Spades}, cardSpec{card.Two, card.Spades},
+			cardSpec{card.Three, card.Spades}, cardSpec{card.Four, card.Spades}), "One-card ace"},
+	}
+	for i := 1; i < len(hands); i++ {
+		better, worse := e.Value(hands[i-1].cards), e.Value(hands[i].cards)
+		if better >= worse {
+			t.Errorf("Expected %s to beat %s, values %d and %d", hands[i-1].desc, hands[i].desc, better, worse)
+		}
+	}
+	if got := Size(e.Value(hands[2].cards)); got != 3 {
+		t.Errorf("Expected a three-card badugi, got %d cards", got)
+	}
+	if v := e.Value(hands[0].cards[:3]); v != handrank.HandValue(^uint64(0)) {
+		t.Errorf("Expected max value for a three-card hand, got %d", v)
+	}
+}
+
+func TestAceHighBadugi(t *testing.T) {
+	e := NewAceHighEvaluator()
+
+	// Best to worst with the ace above the king
+	hands := [][]card.Card{
+		makeHand(cardSpec{card.Five, card.Spades}, cardSpec{card.Four, card.Hearts},
+			cardSpec{card.Three, card.Diamonds}, cardSpec{card.Two, card.Clubs}),
+		makeHand(cardSpec{card.King, card.Spades}, cardSpec{card.Four, card.Hearts},
+			cardSpec{card.Three, card.Diamonds}, cardSpec{card.Two, card.Clubs}),
+		makeHand(cardSpec{card.Ace, card.Spades}, cardSpec{card.Four, card.Hearts},
+			cardSpec{card.Three, card.Diamonds}, cardSpec{card.Two, card.Clubs}),
+		makeHand(cardSpec{card.Four, card.Spades}, cardSpec{card.Three, card.Hearts},
+			cardSpec{card.Two, card.Diamonds}, cardSpec{card.Two, card.Clubs}),
+	}
+	for i := 1; i < len(hands); i++ {
+		if better, worse := e.Value(hands[i-1]), e.Value(hands[i]); better >= worse {
+			t.Errorf("Expected %v to beat %v, values %d and %d", hands[i-1], hands[i], better, worse)
+		}
+	}
+}
+
+func TestBadugiAgainstReference(t *testing.T) {
+	e := NewEvaluator()
+	rng := rand.New(rand.NewSource(38))
+	deck := make([]card.Card, 52)
+	for i := range deck {
+		deck[i] = card.Card(i)
+	}
+	for trial := 0; trial < 20000; trial++ {
+		rng.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+		a, b := deck[:4+rng.Intn(2)], deck[5:9+rng.Intn(2)]
+		sizeA, ranksA := referenceValue(a)
+		sizeB, ranksB := referenceValue(b)
+		want := sizeA > sizeB || (sizeA == sizeB && lowerRanks(ranksA, ranksB))
+		if got := e.Value(a) < e.Value(b); got != want {
+			t.Fatalf("Expected %v beats %v to be %v", a, b, want)
+		}
+		if Size(e.Value(a)) != sizeA {
+			t.Fatalf("Expected %v to play %d cards, got %d", a, sizeA, Size(e.Value(a)))
+		}
+	}
+}
+
+func TestSplitShowdown(t *testing.T) {
+	e := NewSplitEvaluator()
+
+	// 7-5-4-3-2 rainbow with a four-card badugi of 5-4-3-2
+	nuts := makeHand(cardSpec{card.Seven, card.Spades}, cardSpec{card.Five, card.Hearts},
+		cardSpec{card.Four, card.Diamonds}, cardSpec{card.Three, card.Clubs}, cardSpec{card.Two, card.Spades})
+	// A-2-3-4-5: a wheel for badacey, an ace-high 2-7 hand, and a four-card
+	// badugi that must use the ace of spades
+	wheel := makeHand(cardSpec{card.Ace, card.Spades}, cardSpec{card.Two, card.Hearts},
+		cardSpec{card.Three, card.Diamonds}, cardSpec{card.Four, card.Clubs}, cardSpec{card.Five, card.Clubs})
+
+	// In badeucey the ace plays high on both halves, so 7-5-4-3-2 wins the
+	// low and its 5-4-3-2 badugi beats A-4-3-2 to scoop
+	badeucey := []SplitValue{e.Evaluate(Badeucey, nuts), e.Evaluate(Badeucey, wheel)}
+	if got, want := SplitShowdown(101, badeucey), []int{101, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected badeucey payouts %v, got %v", want, got)
+	}
+
+	// In badacey the ace plays low on both halves, so the wheel scoops
+	badacey := []SplitValue{e.Evaluate(Badacey, nuts), e.Evaluate(Badacey, wheel)}
+	if got, want := SplitShowdown(101, badacey), []int{0, 101}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected badacey payouts %v, got %v", want, got)
+	}
+
+	// A tied badugi half is shared, with the odd chip to the earlier seat
+	tied := []SplitValue{{Lowball: 10, Badugi: 5}, {Lowball: 20, Badugi: 5}}
+	if got, want := SplitShowdown(101, tied), []int{76, 25}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected tied payouts %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,101 @@
+package badugi
+
+import (
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+// SplitGame is a five-card draw game whose pot is split between a five-card
+// lowball hand and a badugi
+type SplitGame int
+
+const (
+	// Badeucey splits the pot between the best 2-7 low and the best badugi
+	Badeucey SplitGame = iota
+	// Badacey splits the pot between the best A-5 low and the best badugi
+	Badacey
+)
+
+func (g SplitGame) String() string {
+	switch g {
+	case Badeucey:
+		return "Badeucey"
+	case Badacey:
+		return "Badacey"
+	}
+	return "Unknown"
+}
+
+// SplitValue is a hand's value in each half of a split game. Both halves
+// are lowball values where lower is better.
+type SplitValue struct {
+	Lowball handrank.HandValue
+	Badugi  handrank.HandValue
+}
+
+// SplitEvaluator scores five-card hands for badeucey and badacey. The ace
+// plays high on both halves of badeucey and low on both halves of badacey.
+type SplitEvaluator struct {
+	badugi     *Evaluator
+	highBadugi *Evaluator
+	deuce      *deucelowsingle.HashTable
+	aceFive    *deucelowsingle.AceFiveTable
+}
+
+// NewSplitEvaluator builds the badugi, 2-7 and A-5 tables
+func NewSplitEvaluator() *SplitEvaluator {
+	return &SplitEvaluator{
+		badugi:     NewEvaluator(),
+		highBadugi: NewAceHighEvaluator(),
+		deuce:      deucelowsingle.NewHashTable(),
+		aceFive:    deucelowsingle.NewAceFiveTable(),
+	}
+}
+
+// Evaluate returns the lowball value of all five cards and the best badugi
+// that can be made from them
+func (e *SplitEvaluator) Evaluate(game SplitGame, cards []card.Card) SplitValue {
+	if game == Badacey {
+		return SplitValue{Lowball: e.aceFive.Value(cards), Badugi: e.badugi.Value(cards)}
+	}
+	return SplitValue{Lowball: e.deuce.Value(cards), Badugi: e.highBadugi.Value(cards)}
+}
+
+// SplitShowdown splits a pot among players still in the hand. Values are in
+// seat order starting left of the button. Half the pot goes to the best
+// lowball hand and half to the best badugi, with the odd chip going to the
+// badugi half. Neither half has a qualifier, so the pot is always split
+// unless one player wins both halves. It returns each player's payout.
+func SplitShowdown(pot int, values []SplitValue) []int {
+	payouts := make([]int, len(values))
+	if len(values) == 0 {
+		return payouts
+	}
+
+	lowballWinners, badugiWinners := SplitWinners(values)
+	lowballHalf := pot / 2
+	handrank.SplitPot(payouts, lowballHalf, lowballWinners)
+	handrank.SplitPot(payouts, pot-lowballHalf, badugiWinners)
+	return payouts
+}
+
+// SplitWinners returns the seats holding the best lowball hand and the seats
+// holding the best badugi
+func SplitWinners(values []SplitValue) (lowballWinners, badugiWinners []int) {
+	for i, v := range values {
+		switch {
+		case len(lowballWinners) == 0 || v.Lowball < values[lowballWinners[0]].Lowball:
+			lowballWinners = []int{i}
+		case v.Lowball == values[lowballWinners[0]].Lowball:
+			lowballWinners = append(lowballWinners, i)
+		}
+		switch {
+		case len(badugiWinners) == 0 || v.Badugi < values[badugiWinners[0]].Badugi:
+			badugiWinners = []int{i}
+		case v.Badugi == values[badugiWinners[0]].Badugi:
+			badugiWinners = append(badugiWinners, i)
+		}
+	}
+	return lowballWinners, badugiWinners
+}
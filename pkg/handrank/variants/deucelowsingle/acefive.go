@@ -0,0 +1,107 @@
+package deucelowsingle
+
+import (
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+)
+
+// eightLowLimit is the A-5 value of 8-7-6-5-4, the worst qualifying low
+var eightLowLimit = calculateAceFiveValue([]uint8{0, 0, 0, 1, 1, 1, 1, 1, 0, 0, 0, 0, 0})
+
+// AceFiveTable evaluates A-5 lowball hands, where aces play low and straights
+// and flushes do not count against a hand. Lower values are better.
+type AceFiveTable struct {
+	table []HandValue
+}
+
+// NewAceFiveTable builds the A-5 lookup table. Suits never matter, so a
+// single table indexed by rank multiset covers every hand.
+func NewAceFiveTable() *AceFiveTable {
+	t := &AceFiveTable{
+		table: make([]HandValue, handrank.RankMultisets(handSize)),
+	}
+	for i := range t.table {
+		t.table[i] = HandValue(^uint64(0))
+	}
+
+	var generateCombinations func(pos int, remaining int, ranks []uint8)
+	generateCombinations = func(pos int, remaining int, ranks []uint8) {
+		if remaining == 0 {
+			t.table[encodeRankCounts(ranks)] = calculateAceFiveValue(ranks)
+			return
+		}
+
+		if pos >= 13 {
+			return
+		}
+
+		maxCards := min(4, remaining)
+		for count := uint8(0); count <= uint8(maxCards); count++ {
+			ranks[pos] = count
+			generateCombinations(pos+1, remaining-int(count), ranks)
+			ranks[pos] = 0
+		}
+	}
+
+	generateCombinations(0, handSize, make([]uint8, 13))
+	return t
+}
+
+// Rules describes A-5 lowball hands, in which suits never matter
+func (t *AceFiveTable) Rules() handrank.GameRules {
+	return handrank.GameRules{
+		MaxCards:  handSize,
+		MinCards:  handSize,
+		HandSize:  handSize,
+		IsLowball: true,
+	}
+}
+
+// Value returns the pre-computed A-5 value for a hand
+func (t *AceFiveTable) Value(cards []card.Card) HandValue {
+	if len(cards) != handSize {
+		return HandValue(^uint64(0)) // Return max value for invalid hands
+	}
+	var counts [13]uint8
+	for _, c := range cards {
+		if !validCard(c) {
+			return HandValue(^uint64(0))
+		}
+		counts[c.Rank()]++
+	}
+	return t.table[encodeRankCounts(counts[:])]
+}
+
+// RankValue returns the A-5 value of five ranks given as per-rank counts
+// indexed by card rank
+func (t *AceFiveTable) RankValue(counts []uint8) HandValue {
+	return t.table[encodeRankCounts(counts)]
+}
+
+// EightOrBetter reports whether an A-5 value is a qualifying low: five
+// unpaired cards, all eight or lower
+func EightOrBetter(v HandValue) bool {
+	return v <= eightLowLimit
+}
+
+func calculateAceFiveValue(ranks []uint8) HandValue {
+	penalty, _ := getHandPattern(ranks)
+
+	// Straights are not penalised in A-5
+	if penalty == straightPenalty {
+		penalty = 0
+	}
+
+	// The ace plays low, so cards rank from ace as 1 up to king as 13,
+	// with larger groups first and higher ranks first within a group
+	rankList := make([]int, 0, handSize)
+	for count := uint8(4); count > 0; count-- {
+		for rank := 12; rank >= 0; rank-- {
+			for j := uint8(0); ranks[rank] == count && j < count; j++ {
+				rankList = append(rankList, rank+1)
+			}
+		}
+	}
+
+	return HandValue(penalty + rankValue(rankList))
+}
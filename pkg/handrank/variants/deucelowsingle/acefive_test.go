@@ -0,0 +1,52 @@
+package deucelowsingle
+
+import (
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+func TestAceFiveRankings(t *testing.T) {
+	t5 := NewAceFiveTable()
+
+	// Best to worst
+	hands := []TestHand{
+		{makeHand([]cardSpec{{card.Ace, card.Spades}, {card.Two, card.Spades}, {card.Three, card.Spades},
+			{card.Four, card.Spades}, {card.Five, card.Spades}}), "Wheel, suited (A-2-3-4-5)"},
+		{makeHand([]cardSpec{{card.Ace, card.Spades}, {card.Two, card.Hearts}, {card.Three, card.Diamonds},
+			{card.Four, card.Clubs}, {card.Six, card.Spades}}), "Six low (6-4-3-2-A)"},
+		{makeHand([]cardSpec{{card.Two, card.Spades}, {card.Three, card.Hearts}, {card.Four, card.Diamonds},
+			{card.Five, card.Clubs}, {card.Six, card.Spades}}), "Six low, straight (6-5-4-3-2)"},
+		{makeHand([]cardSpec{{card.Four, card.Spades}, {card.Five, card.Hearts}, {card.Six, card.Diamonds},
+			{card.Seven, card.Clubs}, {card.Eight, card.Spades}}), "Eight low (8-7-6-5-4)"},
+		{makeHand([]cardSpec{{card.Ace, card.Spades}, {card.Two, card.Hearts}, {card.Three, card.Diamonds},
+			{card.Four, card.Clubs}, {card.Nine, card.Spades}}), "Nine low (9-4-3-2-A)"},
+		{makeHand([]cardSpec{{card.Nine, card.Spades}, {card.Ten, card.Hearts}, {card.Jack, card.Diamonds},
+			{card.Queen, card.Clubs}, {card.King, card.Spades}}), "King low (K-Q-J-T-9)"},
+		{makeHand([]cardSpec{{card.Ace, card.Spades}, {card.Ace, card.Hearts}, {card.Two, card.Diamonds},
+			{card.Three, card.Clubs}, {card.Four, card.Spades}}), "Pair of aces (A-A-4-3-2)"},
+		{makeHand([]cardSpec{{card.Two, card.Spades}, {card.Two, card.Hearts}, {card.Ace, card.Diamonds},
+			{card.Three, card.Clubs}, {card.Four, card.Spades}}), "Pair of deuces (2-2-4-3-A)"},
+		{makeHand([]cardSpec{{card.Ace, card.Spades}, {card.Ace, card.Hearts}, {card.King, card.Diamonds},
+			{card.King, card.Clubs}, {card.Queen, card.Spades}}), "Two pair (K-K-A-A-Q)"},
+	}
+
+	for i := 1; i < len(hands); i++ {
+		better, worse := t5.Value(hands[i-1].cards), t5.Value(hands[i].cards)
+		if better >= worse {
+			t.Errorf("Expected %s to beat %s, values %d and %d", hands[i-1], hands[i], better, worse)
+		}
+	}
+
+	qualifying := map[string]bool{
+		"Wheel, suited (A-2-3-4-5)":     true,
+		"Six low (6-4-3-2-A)":           true,
+		"Six low, straight (6-5-4-3-2)": true,
+		"Eight low (8-7-6-5-4)":         true,
+	}
+	for _, h := range hands {
+		if got := EightOrBetter(t5.Value(h.cards)); got != qualifying[h.desc] {
+			t.Errorf("EightOrBetter(%s) = %v, want %v", h, got, qualifying[h.desc])
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package deucelowsingle
+
+import (
+	"math/bits"
+	"sync"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+// minParallelChunk is the smallest number of hands handed to a single worker
+const minParallelChunk = 4096
+
+// HandMask packs cards into a card-set mask where bit i is set for card.Card(i)
+func HandMask(cards []card.Card) uint64 {
+	var mask uint64
+	for _, c := range cards {
+		mask |= 1 << uint(c)
+	}
+	return mask
+}
+
+// ValueBatch evaluates a flat slice of five-card hands, writing the value of
+// cards[i*5:i*5+5] into out[i]. It returns the number of hands evaluated,
+// which is the smaller of len(cards)/5 and len(out). Unlike Value it does not
+// check card codes, so callers must only pass cards from a standard deck.
+func (ht *HashTable) ValueBatch(cards []card.Card, out []HandValue) int {
+	n := min(len(cards)/handSize, len(out))
+	ht.valueRange(cards, out, 0, n)
+	return n
+}
+
+// ValueBatchParallel is ValueBatch split into chunks across up to workers
+// goroutines. A workers value below 2 evaluates on the calling goroutine.
+func (ht *HashTable) ValueBatchParallel(cards []card.Card, out []HandValue, workers int) int {
+	n := min(len(cards)/handSize, len(out))
+	parallelChunks(n, workers, func(lo, hi int) {
+		ht.valueRange(cards, out, lo, hi)
+	})
+	return n
+}
+
+// ValueMask returns the value of a hand given as a card-set mask. Masks that
+// do not hold exactly five cards get the same max value as Value.
+func (ht *HashTable) ValueMask(mask uint64) HandValue {
+	if bits.OnesCount64(mask) != handSize || mask>>deckSize != 0 {
+		return HandValue(^uint64(0))
+	}
+
+	var hand [handSize]card.Card
+	for i := range hand {
+		hand[i] = card.Card(bits.TrailingZeros64(mask))
+		mask &= mask - 1
+	}
+	return ht.value(hand[:])
+}
+
+// ValueMaskBatch evaluates masks[i] into out[i] and returns the number of
+// hands evaluated
+func (ht *HashTable) ValueMaskBatch(masks []uint64, out []HandValue) int {
+	n := min(len(masks), len(out))
+	for i := 0; i < n; i++ {
+		out[i] = ht.ValueMask(masks[i])
+	}
+	return n
+}
+
+// ValueMaskBatchParallel is ValueMaskBatch split across up to workers goroutines
+func (ht *HashTable) ValueMaskBatchParallel(masks []uint64, out []HandValue, workers int) int {
+	n := min(len(masks), len(out))
+	parallelChunks(n, workers, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			out[i] = ht.ValueMask(masks[i])
+		}
+	})
+	return n
+}
+
+// valueRange evaluates hands lo through hi-1 of a flat card slice
+func (ht *HashTable) valueRange(cards []card.Card, out []HandValue, lo, hi int) {
+	for i := lo; i < hi; i++ {
+		out[i] = ht.value(cards[i*handSize : i*handSize+handSize])
+	}
+}
+
+// parallelChunks splits [0, n) into contiguous chunks and runs fn on each,
+// one goroutine per chunk, waiting for all of them to finish
+func parallelChunks(n, workers int, fn func(lo, hi int)) {
+	if workers > n/minParallelChunk {
+		workers = n / minParallelChunk
+	}
+	if workers < 2 {
+		fn(0, n)
+		return
+	}
+
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for lo := 0; lo < n; lo += chunk {
+		hi := min(lo+chunk, n)
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			fn(lo, hi)
+		}(lo, hi)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,106 @@
+package deucelowsingle
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+// randomHands deals n independent five-card hands into one flat slice
+func randomHands(rng *rand.Rand, n int) []card.Card {
+	cards := make([]card.Card, 0, n*handSize)
+	for i := 0; i < n; i++ {
+		perm := rng.Perm(52)
+		for _, idx := range perm[:handSize] {
+			cards = append(cards, card.Card(idx))
+		}
+	}
+	return cards
+}
+
+func TestValueBatch(t *testing.T) {
+	ht := NewHashTable()
+	rng := rand.New(rand.NewSource(1))
+	const n = 20000
+	cards := randomHands(rng, n)
+
+	expected := make([]HandValue, n)
+	for i := range expected {
+		expected[i] = ht.Value(cards[i*handSize : i*handSize+handSize])
+	}
+
+	t.Run("Sequential", func(t *testing.T) {
+		out := make([]HandValue, n)
+		if got := ht.ValueBatch(cards, out); got != n {
+			t.Fatalf("Expected %d hands evaluated, got %d", n, got)
+		}
+		for i := range out {
+			if out[i] != expected[i] {
+				t.Fatalf("Hand %d: batch value %d, Value %d", i, out[i], expected[i])
+			}
+		}
+	})
+
+	t.Run("Parallel", func(t *testing.T) {
+		out := make([]HandValue, n)
+		if got := ht.ValueBatchParallel(cards, out, 4); got != n {
+			t.Fatalf("Expected %d hands evaluated, got %d", n, got)
+		}
+		for i := range out {
+			if out[i] != expected[i] {
+				t.Fatalf("Hand %d: parallel value %d, Value %d", i, out[i], expected[i])
+			}
+		}
+	})
+
+	t.Run("Masks", func(t *testing.T) {
+		masks := make([]uint64, n)
+		for i := range masks {
+			masks[i] = HandMask(cards[i*handSize : i*handSize+handSize])
+		}
+		out := make([]HandValue, n)
+		ht.ValueMaskBatchParallel(masks, out, 4)
+		for i := range out {
+			if out[i] != expected[i] {
+				t.Fatalf("Hand %d: mask value %d, Value %d", i, out[i], expected[i])
+			}
+		}
+	})
+
+	t.Run("Short Output", func(t *testing.T) {
+		out := make([]HandValue, 10)
+		if got := ht.ValueBatch(cards, out); got != 10 {
+			t.Errorf("Expected evaluation to stop at output length 10, got %d", got)
+		}
+		if got := ht.ValueBatch(cards[:12], make([]HandValue, 5)); got != 2 {
+			t.Errorf("Expected trailing partial hand to be ignored, got %d hands", got)
+		}
+	})
+
+	t.Run("Invalid Mask", func(t *testing.T) {
+		if v := ht.ValueMask(HandMask(cards[:4])); v != HandValue(^uint64(0)) {
+			t.Errorf("Expected max value for a four card mask, got %d", v)
+		}
+	})
+}
+
+func BenchmarkValue(b *testing.B) {
+	ht := NewHashTable()
+	cards := randomHands(rand.New(rand.NewSource(1)), 1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		j := (i % 1024) * handSize
+		ht.Value(cards[j : j+handSize])
+	}
+}
+
+func BenchmarkValueBatch(b *testing.B) {
+	ht := NewHashTable()
+	cards := randomHands(rand.New(rand.NewSource(1)), 1024)
+	out := make([]HandValue, 1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 1024 {
+		ht.ValueBatch(cards, out)
+	}
+}
@@ -0,0 +1,55 @@
+package deucelowsingle
+
+import "github.com/dgunzy/card/pkg/card"
+
+// CategorySize is the value span of one 2-7 hand category
+const CategorySize = pairPenalty
+
+// Category is the class of a 2-7 hand, from HighCard, the only hands that
+// are not penalised, down to StraightFlush
+type Category int
+
+const (
+	HighCard Category = iota
+	Pair
+	TwoPair
+	Trips
+	Straight
+	Flush
+	FullHouse
+	Quads
+	StraightFlush
+)
+
+var categoryNames = [...]string{
+	"High Card", "Pair", "Two Pair", "Three of a Kind", "Straight",
+	"Flush", "Full House", "Four of a Kind", "Straight Flush",
+}
+
+func (c Category) String() string {
+	if c < HighCard || c > StraightFlush {
+		return "Unknown"
+	}
+	return categoryNames[c]
+}
+
+// CategoryOf returns the category of a value produced by a HashTable
+func CategoryOf(v HandValue) Category {
+	return Category(uint64(v) / CategorySize)
+}
+
+// Ranks returns the five ranks of a value produced by a HashTable in order
+// of significance: larger groups first, then higher ranks first
+func Ranks(v HandValue) []card.Rank {
+	ranks := make([]card.Rank, handSize)
+	rest := uint64(v) % CategorySize
+	for i := handSize - 1; i >= 0; i-- {
+		r := int(rest % 14)
+		rest /= 14
+		if r == 13 { // The ace plays high
+			r = 0
+		}
+		ranks[i] = card.Rank(r)
+	}
+	return ranks
+}
@@ -4,6 +4,7 @@ import (
 	"sort"
 
 	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
 )
 
 const (
@@ -18,9 +19,13 @@ const (
 	straightFlushPenalty = uint64(8000000)
 
 	handSize = 5
+	deckSize = 52
 )
 
-type HandValue uint64
+// HandValue is a 2-7 or A-5 hand value, where lower is better. It is an
+// alias of handrank.HandValue, so that the tables here are handrank
+// evaluators; conversions such as HandValue(v) work as they always have.
+type HandValue = handrank.HandValue
 
 type HashTable struct {
 	flushTable    []HandValue
@@ -30,8 +35,8 @@ type HashTable struct {
 // Initialize the lookup tables
 func NewHashTable() *HashTable {
 	ht := &HashTable{
-		flushTable:    make([]HandValue, 8192),  // 2^13 possible flush combinations
-		nonFlushTable: make([]HandValue, 49205), // All possible 5-card combinations
+		flushTable:    make([]HandValue, 8192),                             // 2^13 possible flush combinations
+		nonFlushTable: make([]HandValue, handrank.RankMultisets(handSize)), // All 5-card rank multisets
 	}
 
 	// Initialize all values to maximum (worst possible hand)
@@ -47,12 +52,37 @@ func NewHashTable() *HashTable {
 	return ht
 }
 
+// Rules describes 2-7 single draw hands
+func (ht *HashTable) Rules() handrank.GameRules {
+	return handrank.GameRules{
+		MaxCards:  handSize,
+		MinCards:  handSize,
+		UseSuits:  true,
+		HandSize:  handSize,
+		IsLowball: true,
+	}
+}
+
 // Value returns the pre-computed value for a hand
 func (ht *HashTable) Value(cards []card.Card) HandValue {
 	if len(cards) != handSize {
 		return HandValue(^uint64(0)) // Return max value for invalid hands
 	}
+	for _, c := range cards {
+		if !validCard(c) {
+			return HandValue(^uint64(0))
+		}
+	}
+	return ht.value(cards)
+}
 
+// validCard reports whether c is one of the 52 cards of a standard deck
+func validCard(c card.Card) bool {
+	return int(c) < deckSize
+}
+
+// value looks up a hand that is already known to hold exactly five cards
+func (ht *HashTable) value(cards []card.Card) HandValue {
 	// Fast flush check using XOR
 	suit := cards[0].Suit()
 	isFlush := true
@@ -66,7 +96,7 @@ func (ht *HashTable) Value(cards []card.Card) HandValue {
 	if isFlush {
 		return ht.flushTable[getRankBinary(cards)]
 	}
-	return ht.nonFlushTable[getRankQuinary(cards)]
+	return ht.nonFlushTable[getRankIndex(cards)]
 }
 
 func (ht *HashTable) initializeFlushTable() {
@@ -93,7 +123,7 @@ func (ht *HashTable) initializeNonFlushTable() {
 	var generateCombinations func(pos int, remaining int, ranks []uint8)
 	generateCombinations = func(pos int, remaining int, ranks []uint8) {
 		if remaining == 0 {
-			index := encodeQuinary(ranks)
+			index := encodeRankCounts(ranks)
 			value := calculateNonFlushValue(ranks)
 			ht.nonFlushTable[index] = value
 			return
@@ -124,21 +154,28 @@ func getRankBinary(cards []card.Card) uint16 {
 	return binary
 }
 
-// getRankQuinary converts 5 cards to 13-bit quinary (base-5)
-func getRankQuinary(cards []card.Card) uint32 {
-	counts := make([]uint8, 13)
+// getRankIndex converts 5 cards to their index in the non-flush table
+func getRankIndex(cards []card.Card) uint32 {
+	var counts [13]uint8
 	for _, c := range cards {
 		counts[c.Rank()]++
 	}
-	return encodeQuinary(counts)
+	return encodeRankCounts(counts[:])
 }
 
-// isSequential checks if a sorted slice of ranks forms a straight
+// lowballRank maps a card rank to its 2-7 strength, where the ace plays high
+func lowballRank(rank int) int {
+	if rank == 0 { // Ace
+		return 13
+	}
+	return rank
+}
+
+// isSequential checks if a slice of ranks sorted high to low forms a straight
 func isSequential(ranks []int) bool {
 	if len(ranks) < handSize {
 		return false
 	}
-	// Since ranks are now sorted high to low, adjust comparison
 	for i := 1; i < len(ranks); i++ {
 		if ranks[i-1] != ranks[i]+1 {
 			return false
@@ -147,31 +184,38 @@ func isSequential(ranks []int) bool {
 	return true
 }
 
-// getHandPattern returns penalties for pairs/trips/etc and card ranks sorted high to low
+// getHandPattern returns penalties for pairs/trips/etc and the 2-7 ranks
+// ordered by significance: larger groups first, then higher ranks first
 func getHandPattern(ranks []uint8) (uint64, []int) {
 	var penalty uint64
-	rankCounts := make(map[int]int)
-	rankList := make([]int, 0)
+	rankList := make([]int, 0, handSize)
 
-	// Count ranks and build rank list
+	// Build rank list with the ace playing high
 	for i, count := range ranks {
-		if count > 0 {
-			rankCounts[i] = int(count)
-			for j := uint8(0); j < count; j++ {
-				rankList = append(rankList, i)
-			}
+		for j := uint8(0); j < count; j++ {
+			rankList = append(rankList, lowballRank(i))
 		}
 	}
 
-	// Sort ranks high to low for 2-7 comparison
-	sort.Sort(sort.Reverse(sort.IntSlice(rankList)))
+	counts := make(map[int]int)
+	for _, rank := range rankList {
+		counts[rank]++
+	}
+
+	// Pairs and trips are compared before kickers
+	sort.Slice(rankList, func(i, j int) bool {
+		if counts[rankList[i]] != counts[rankList[j]] {
+			return counts[rankList[i]] > counts[rankList[j]]
+		}
+		return rankList[i] > rankList[j]
+	})
 
 	// Count pairs, trips, etc
 	pairs := 0
 	trips := 0
 	quads := 0
 
-	for _, count := range rankCounts {
+	for _, count := range counts {
 		switch count {
 		case 2:
 			pairs++
@@ -193,38 +237,26 @@ func getHandPattern(ranks []uint8) (uint64, []int) {
 		penalty = twoPairPenalty
 	} else if pairs == 1 {
 		penalty = pairPenalty
-	}
-
-	// Check for straight
-	if isSequential(rankList) {
-		if penalty == 0 {
-			penalty = straightPenalty
-		} else if straightPenalty > penalty {
-			penalty = straightPenalty
-		}
+	} else if isSequential(rankList) {
+		penalty = straightPenalty
 	}
 
 	return penalty, rankList
 }
 
-func calculateNonFlushValue(ranks []uint8) HandValue {
-	penalty, rankList := getHandPattern(ranks)
-
-	// Calculate base value from card ranks
-	var value uint64 = penalty
-	multiplier := uint64(1)
-
-	// Add values for each card, highest first
+// rankValue orders hands within a penalty class, weighting the most
+// significant rank highest
+func rankValue(rankList []int) uint64 {
+	var value uint64
 	for _, rank := range rankList {
-		adjustedRank := rank
-		if rank == 0 { // Ace
-			adjustedRank = 13
-		}
-		value += uint64(adjustedRank) * multiplier
-		multiplier *= 14 // Use 14 to ensure unique values
+		value = value*14 + uint64(rank) // Use 14 to ensure unique values
 	}
+	return value
+}
 
-	return HandValue(value)
+func calculateNonFlushValue(ranks []uint8) HandValue {
+	penalty, rankList := getHandPattern(ranks)
+	return HandValue(penalty + rankValue(rankList))
 }
 
 func calculateFlushValue(binary uint16) HandValue {
@@ -245,28 +277,11 @@ func calculateFlushValue(binary uint16) HandValue {
 		penalty = straightFlushPenalty
 	}
 
-	var value uint64 = penalty
-	multiplier := uint64(1)
-
-	// Add values for each card, highest first
-	for _, rank := range rankList {
-		adjustedRank := rank
-		if rank == 0 { // Ace
-			adjustedRank = 13
-		}
-		value += uint64(adjustedRank) * multiplier
-		multiplier *= 14
-	}
-
-	return HandValue(value)
+	return HandValue(penalty + rankValue(rankList))
 }
 
-// encodeQuinary converts rank counts to a quinary number
-func encodeQuinary(ranks []uint8) uint32 {
-	// We need to convert the ranks into a unique index within our table size
-	// The pattern here is that we're mapping all possible 5-card combinations
-	// where each position (rank) can have 0-4 cards
-
+// encodeRankCounts converts 5 rank counts to a unique non-flush table index
+func encodeRankCounts(ranks []uint8) uint32 {
 	// Count total cards to validate
 	total := uint8(0)
 	for _, count := range ranks {
@@ -275,25 +290,7 @@ func encodeQuinary(ranks []uint8) uint32 {
 	if total != 5 {
 		return 0 // Invalid hand
 	}
-
-	// Calculate unique index using combinatorial number system
-	var index uint32
-	remainingCards := uint32(5)
-	multiplier := uint32(1)
-
-	for i := 0; i < 13 && remainingCards > 0; i++ {
-		count := uint32(ranks[i])
-		if count > 0 {
-			// For each position, we multiply by combinations of remaining positions
-			for j := uint32(0); j < count; j++ {
-				index += multiplier * uint32(i)
-				multiplier *= 13
-			}
-			remainingCards -= count
-		}
-	}
-
-	return index % 49205 // Ensure we stay within table bounds
+	return handrank.EncodeRankCounts(ranks)
 }
 
 // Helper function for min value
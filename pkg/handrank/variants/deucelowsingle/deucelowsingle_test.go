@@ -2,6 +2,7 @@ package deucelowsingle
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
 	"testing"
 
@@ -132,13 +133,13 @@ func runExtendedTestCases(t *testing.T, rankedHands []rankedHand) {
 			}
 		}
 
-		// A pair of 2s with high kickers should be better than a pair of 2s with low kickers
-		if lowPairHighKickers.value >= lowPairLowKickers.value {
+		// A pair of 2s with low kickers should be better than a pair of 2s with high kickers
+		if lowPairLowKickers.value >= lowPairHighKickers.value {
 			t.Errorf("Hand comparison error - better hand rated worse:"+
 				"\nExpected better hand:%s"+
 				"\nRated worse than:%s",
-				formatDetailedHand(lowPairHighKickers.hand, lowPairHighKickers.value),
-				formatDetailedHand(lowPairLowKickers.hand, lowPairLowKickers.value))
+				formatDetailedHand(lowPairLowKickers.hand, lowPairLowKickers.value),
+				formatDetailedHand(lowPairHighKickers.hand, lowPairHighKickers.value))
 		}
 
 		// A pair of Kings with low kickers should be worse than any pair of 2s
@@ -208,3 +209,71 @@ func makeHand(specs []cardSpec) []card.Card {
 	}
 	return cards
 }
+
+func TestCategoryAndRanks(t *testing.T) {
+	ht := NewHashTable()
+	tests := []struct {
+		hand     []cardSpec
+		category Category
+		ranks    []card.Rank
+	}{
+		{[]cardSpec{{card.Seven, card.Spades}, {card.Five, card.Hearts}, {card.Four, card.Diamonds},
+			{card.Three, card.Clubs}, {card.Two, card.Spades}},
+			HighCard, []card.Rank{card.Seven, card.Five, card.Four, card.Three, card.Two}},
+		{[]cardSpec{{card.Ace, card.Spades}, {card.Two, card.Hearts}, {card.Three, card.Diamonds},
+			{card.Four, card.Clubs}, {card.Five, card.Spades}},
+			HighCard, []card.Rank{card.Ace, card.Five, card.Four, card.Three, card.Two}},
+		{[]cardSpec{{card.Two, card.Spades}, {card.Two, card.Hearts}, {card.King, card.Diamonds},
+			{card.Four, card.Clubs}, {card.Three, card.Spades}},
+			Pair, []card.Rank{card.Two, card.Two, card.King, card.Four, card.Three}},
+		{[]cardSpec{{card.Six, card.Spades}, {card.Five, card.Hearts}, {card.Four, card.Diamonds},
+			{card.Three, card.Clubs}, {card.Two, card.Spades}},
+			Straight, []card.Rank{card.Six, card.Five, card.Four, card.Three, card.Two}},
+		{[]cardSpec{{card.Eight, card.Spades}, {card.Five, card.Spades}, {card.Four, card.Spades},
+			{card.Three, card.Spades}, {card.Two, card.Spades}},
+			Flush, []card.Rank{card.Eight, card.Five, card.Four, card.Three, card.Two}},
+	}
+	for _, tt := range tests {
+		v := ht.Value(makeHand(tt.hand))
+		if got := CategoryOf(v); got != tt.category {
+			t.Errorf("Expected %v, got %v", tt.category, got)
+		}
+		if got := Ranks(v); !reflect.DeepEqual(got, tt.ranks) {
+			t.Errorf("Expected ranks %v, got %v", tt.ranks, got)
+		}
+	}
+}
+
+func TestShowdown(t *testing.T) {
+	ht := NewHashTable()
+	sevenFive := []card.Card{
+		card.NewCard(card.Spades, card.Seven), card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Hearts, card.Four), card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Diamonds, card.Two),
+	}
+	sameSevenFive := []card.Card{
+		card.NewCard(card.Clubs, card.Seven), card.NewCard(card.Diamonds, card.Five),
+		card.NewCard(card.Spades, card.Four), card.NewCard(card.Spades, card.Three),
+		card.NewCard(card.Hearts, card.Two),
+	}
+	eightLow := []card.Card{
+		card.NewCard(card.Spades, card.Eight), card.NewCard(card.Hearts, card.Six),
+		card.NewCard(card.Hearts, card.Four), card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Diamonds, card.Two),
+	}
+
+	if ht.Compare(sevenFive, eightLow) != 1 || ht.Compare(eightLow, sevenFive) != -1 {
+		t.Errorf("Expected 7-5 to beat 8-6")
+	}
+	if ht.Compare(sevenFive, sameSevenFive) != 0 {
+		t.Errorf("Expected identical ranks to tie")
+	}
+	if ht.Compare(eightLow, sevenFive[:4]) != 1 {
+		t.Errorf("Expected an invalid hand to lose")
+	}
+
+	s := ht.Showdown(eightLow, sevenFive, sameSevenFive)
+	if !reflect.DeepEqual(s.Winners, []int{1, 2}) || !reflect.DeepEqual(s.Payouts(10), []int{0, 5, 5}) {
+		t.Errorf("Expected the 7-5s to split, got winners %v", s.Winners)
+	}
+}
@@ -0,0 +1,305 @@
+package deucelowsingle
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Predicate reports whether a 2-7 value belongs to a set of hands
+type Predicate func(HandValue) bool
+
+// Compile parses an expression in the language players use for 2-7 hands
+// and returns the set of values it describes. Hands are named by their top
+// cards, such as "8-6", "7-low", "any 8" or "A-high", which match unpaired
+// hands starting with those cards, or by category, such as "pair", "two
+// pair" or "straight". A hand may be preceded by a comparison, "≤ 8-6",
+// "< 9", "better than 9-low", "worse than pair", or followed by "or better"
+// or "or worse". Terms combine with "and", "or", "not" or "no", and
+// parentheses, so "9-low or better", "any pair or worse" and "no straights"
+// are all expressions. Case is ignored, as is a trailing "pat".
+func Compile(expr string) (Predicate, error) {
+	p := &exprParser{src: expr}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	pred, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, p.errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return pred, nil
+}
+
+// MustCompile is like Compile but panics on an invalid expression
+func MustCompile(expr string) Predicate {
+	pred, err := Compile(expr)
+	if err != nil {
+		panic(err)
+	}
+	return pred
+}
+
+type exprParser struct {
+	src    string
+	tokens []string
+	pos    int
+}
+
+func (p *exprParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("deucelowsingle: expression %q: %s", p.src, fmt.Sprintf(format, args...))
+}
+
+// tokenize splits the expression into words, which keep their dashes, and
+// the symbols ( ) < > ≤ ≥ and their two-character forms
+func (p *exprParser) tokenize() error {
+	s := strings.ToLower(p.src)
+	for i := 0; i < len(s); {
+		r := rune(s[i])
+		switch {
+		case r == ' ' || r == '\t':
+			i++
+		case r == '(' || r == ')':
+			p.tokens = append(p.tokens, s[i:i+1])
+			i++
+		case r == '<' || r == '>':
+			if i+1 < len(s) && s[i+1] == '=' {
+				p.tokens = append(p.tokens, s[i:i+2])
+				i += 2
+			} else {
+				p.tokens = append(p.tokens, s[i:i+1])
+				i++
+			}
+		case strings.HasPrefix(s[i:], "≤"):
+			p.tokens = append(p.tokens, "<=")
+			i += len("≤")
+		case strings.HasPrefix(s[i:], "≥"):
+			p.tokens = append(p.tokens, ">=")
+			i += len("≥")
+		case r == '-' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			j := i
+			for j < len(s) && (s[j] == '-' || unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j]))) {
+				j++
+			}
+			p.tokens = append(p.tokens, s[i:j])
+			i = j
+		default:
+			return p.errorf("unexpected character %q", s[i:i+1])
+		}
+	}
+	if len(p.tokens) == 0 {
+		return p.errorf("empty expression")
+	}
+	return nil
+}
+
+func (p *exprParser) peek(words ...string) bool {
+	if p.pos+len(words) > len(p.tokens) {
+		return false
+	}
+	for i, w := range words {
+		if p.tokens[p.pos+i] != w {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *exprParser) accept(words ...string) bool {
+	if p.peek(words...) {
+		p.pos += len(words)
+		return true
+	}
+	return false
+}
+
+// or parses terms joined by "or", leaving "or better" and "or worse" to the
+// term they follow
+func (p *exprParser) or() (Predicate, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for !p.peek("or", "better") && !p.peek("or", "worse") && p.accept("or") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(v HandValue) bool { return l(v) || right(v) }
+	}
+	return left, nil
+}
+
+func (p *exprParser) and() (Predicate, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and") {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(v HandValue) bool { return l(v) && right(v) }
+	}
+	return left, nil
+}
+
+func (p *exprParser) unary() (Predicate, error) {
+	// "No pair" names unpaired hands rather than negating "pair"
+	if !p.peek("no", "pair") && !p.peek("no", "pairs") && (p.accept("not") || p.accept("no")) {
+		inner, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(v HandValue) bool { return !inner(v) }, nil
+	}
+	if p.accept("(") {
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, p.errorf("missing )")
+		}
+		return inner, nil
+	}
+	return p.term()
+}
+
+// comparisons maps each way of writing a comparison to the values it keeps
+// relative to a hand's span of values, lo to hi
+var comparisons = []struct {
+	words []string
+	keep  func(v, lo, hi HandValue) bool
+}{
+	{[]string{"<="}, func(v, lo, hi HandValue) bool { return v <= hi }},
+	{[]string{"<"}, func(v, lo, hi HandValue) bool { return v < lo }},
+	{[]string{">="}, func(v, lo, hi HandValue) bool { return v >= lo }},
+	{[]string{">"}, func(v, lo, hi HandValue) bool { return v > hi }},
+	{[]string{"better", "than"}, func(v, lo, hi HandValue) bool { return v < lo }},
+	{[]string{"worse", "than"}, func(v, lo, hi HandValue) bool { return v > hi }},
+}
+
+// term parses one hand with an optional comparison
+func (p *exprParser) term() (Predicate, error) {
+	keep := func(v, lo, hi HandValue) bool { return v >= lo && v <= hi }
+	compared := false
+	for _, c := range comparisons {
+		if p.accept(c.words...) {
+			keep, compared = c.keep, true
+			break
+		}
+	}
+
+	lo, hi, err := p.hand()
+	if err != nil {
+		return nil, err
+	}
+	if !compared {
+		switch {
+		case p.accept("or", "better"):
+			keep = comparisons[0].keep
+		case p.accept("or", "worse"):
+			keep = comparisons[2].keep
+		}
+	}
+	p.accept("pat")
+	return func(v HandValue) bool { return keep(v, lo, hi) }, nil
+}
+
+// categoryWords names the categories, longest names first so that "straight
+// flush" is not read as "straight"
+var categoryWords = []struct {
+	words    []string
+	category Category
+}{
+	{[]string{"straight", "flush"}, StraightFlush},
+	{[]string{"straight", "flushes"}, StraightFlush},
+	{[]string{"three", "of", "a", "kind"}, Trips},
+	{[]string{"four", "of", "a", "kind"}, Quads},
+	{[]string{"full", "house"}, FullHouse},
+	{[]string{"full", "houses"}, FullHouse},
+	{[]string{"two", "pair"}, TwoPair},
+	{[]string{"two", "pairs"}, TwoPair},
+	{[]string{"high", "card"}, HighCard},
+	{[]string{"no", "pair"}, HighCard},
+	{[]string{"no", "pairs"}, HighCard},
+	{[]string{"no-pair"}, HighCard},
+	{[]string{"no-pairs"}, HighCard},
+	{[]string{"pair"}, Pair},
+	{[]string{"pairs"}, Pair},
+	{[]string{"trips"}, Trips},
+	{[]string{"straight"}, Straight},
+	{[]string{"straights"}, Straight},
+	{[]string{"flush"}, Flush},
+	{[]string{"flushes"}, Flush},
+	{[]string{"quads"}, Quads},
+}
+
+// hand parses a hand name and returns the span of values it covers
+func (p *exprParser) hand() (lo, hi HandValue, err error) {
+	p.accept("any")
+	for _, c := range categoryWords {
+		if p.accept(c.words...) {
+			lo = HandValue(uint64(c.category) * CategorySize)
+			return lo, lo + HandValue(CategorySize-1), nil
+		}
+	}
+	if p.pos == len(p.tokens) {
+		return 0, 0, p.errorf("missing hand")
+	}
+	word := p.tokens[p.pos]
+	p.pos++
+	// "8 low" as well as "8-low"
+	if !p.accept("low") {
+		p.accept("high")
+	}
+	word = strings.TrimSuffix(strings.TrimSuffix(word, "-low"), "-high")
+
+	var digits []uint64
+	for _, part := range strings.Split(word, "-") {
+		d, ok := lowballDigit(part)
+		if !ok {
+			return 0, 0, p.errorf("unknown hand %q", word)
+		}
+		if len(digits) > 0 && d >= digits[len(digits)-1] {
+			return 0, 0, p.errorf("ranks of %q must fall from the top card", word)
+		}
+		digits = append(digits, d)
+	}
+	if len(digits) > handSize {
+		return 0, 0, p.errorf("%q has more than %d ranks", word, handSize)
+	}
+
+	// Unpaired hands starting with the given ranks span from those ranks
+	// followed by zeros to those ranks followed by the highest digit
+	var low, high uint64
+	for i := 0; i < handSize; i++ {
+		if i < len(digits) {
+			low, high = low*14+digits[i], high*14+digits[i]
+		} else {
+			low, high = low*14, high*14+13
+		}
+	}
+	return HandValue(low), HandValue(high), nil
+}
+
+// lowballDigit reads a rank as its 2-7 strength, with the ace high
+func lowballDigit(s string) (uint64, bool) {
+	if s == "10" {
+		s = "t"
+	}
+	if len(s) != 1 {
+		return 0, false
+	}
+	i := strings.IndexByte("a23456789tjqk", s[0])
+	if i < 0 {
+		return 0, false
+	}
+	return uint64(lowballRank(i)), true
+}
@@ -0,0 +1,78 @@
+package deucelowsingle
+
+import (
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+func TestCompile(t *testing.T) {
+	ht := NewHashTable()
+	hand := func(ranks ...card.Rank) HandValue {
+		suits := []card.Suit{card.Spades, card.Hearts, card.Diamonds, card.Clubs, card.Spades}
+		cards := make([]card.Card, len(ranks))
+		for i, r := range ranks {
+			cards[i] = card.NewCard(suits[i], r)
+		}
+		return ht.Value(cards)
+	}
+	sevenFive := hand(card.Seven, card.Five, card.Four, card.Three, card.Two)
+	eightSix := hand(card.Eight, card.Six, card.Five, card.Three, card.Two)
+	eightSeven := hand(card.Eight, card.Seven, card.Four, card.Three, card.Two)
+	nineLow := hand(card.Nine, card.Eight, card.Seven, card.Six, card.Four)
+	jackLow := hand(card.Jack, card.Six, card.Four, card.Three, card.Two)
+	aceHigh := hand(card.Ace, card.Five, card.Four, card.Three, card.Two)
+	pair := hand(card.Seven, card.Seven, card.Four, card.Three, card.Two)
+	straight := hand(card.Seven, card.Six, card.Five, card.Four, card.Three)
+	flush := ht.Value([]card.Card{
+		card.NewCard(card.Hearts, card.Eight), card.NewCard(card.Hearts, card.Six),
+		card.NewCard(card.Hearts, card.Five), card.NewCard(card.Hearts, card.Three),
+		card.NewCard(card.Hearts, card.Two),
+	})
+
+	tests := []struct {
+		expr string
+		in   []HandValue
+		out  []HandValue
+	}{
+		{"≤ 8-6", []HandValue{sevenFive, eightSix}, []HandValue{eightSeven, nineLow, pair}},
+		{"<= 8-6", []HandValue{eightSix}, []HandValue{eightSeven}},
+		{"< 8-6", []HandValue{sevenFive}, []HandValue{eightSix}},
+		{"7-low", []HandValue{sevenFive}, []HandValue{eightSix, pair, straight}},
+		{"any 8", []HandValue{eightSix, eightSeven}, []HandValue{sevenFive, nineLow}},
+		{"9-low or better", []HandValue{sevenFive, eightSeven, nineLow}, []HandValue{jackLow, pair}},
+		{"better than 9-low", []HandValue{eightSeven}, []HandValue{nineLow}},
+		{"J-low pat", []HandValue{jackLow}, []HandValue{nineLow}},
+		{"A high", []HandValue{aceHigh}, []HandValue{jackLow, pair}},
+		{"any pair or worse", []HandValue{pair, straight, flush}, []HandValue{aceHigh}},
+		{"worse than pair", []HandValue{straight, flush}, []HandValue{pair, sevenFive}},
+		{"no straights", []HandValue{sevenFive, pair, flush}, []HandValue{straight}},
+		{"no pair", []HandValue{aceHigh, sevenFive}, []HandValue{pair, straight}},
+		{"no pairs", []HandValue{aceHigh, sevenFive}, []HandValue{pair, straight, flush}},
+		{"no-pairs", []HandValue{aceHigh, sevenFive}, []HandValue{pair, straight, flush}},
+		{"not (7-low or 8-6) and no pair", []HandValue{eightSeven, aceHigh}, []HandValue{sevenFive, eightSix, pair}},
+		{"Straight or Flush", []HandValue{straight, flush}, []HandValue{pair}},
+	}
+	for _, tt := range tests {
+		pred, err := Compile(tt.expr)
+		if err != nil {
+			t.Fatalf("Unexpected error compiling %q: %v", tt.expr, err)
+		}
+		for _, v := range tt.in {
+			if !pred(v) {
+				t.Errorf("Expected %q to match %v", tt.expr, Ranks(v))
+			}
+		}
+		for _, v := range tt.out {
+			if pred(v) {
+				t.Errorf("Expected %q not to match %v %v", tt.expr, CategoryOf(v), Ranks(v))
+			}
+		}
+	}
+
+	for _, expr := range []string{"", "8-9", "9-low or", "(7-low", "pair pair", "z-low", "8-6 ?"} {
+		if _, err := Compile(expr); err == nil {
+			t.Errorf("Expected an error compiling %q", expr)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package deucelowsingle
+
+import (
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank/internal/reference"
+)
+
+// bytesToCards maps each fuzz byte directly to a card code, so inputs can hold
+// duplicates and codes outside the 52-card deck
+func bytesToCards(data []byte) []card.Card {
+	cards := make([]card.Card, len(data))
+	for i, b := range data {
+		cards[i] = card.Card(b)
+	}
+	return cards
+}
+
+// distinctValidHand reports whether cards is five distinct deck cards
+func distinctValidHand(cards []card.Card) bool {
+	if len(cards) != handSize {
+		return false
+	}
+	seen := make(map[card.Card]bool)
+	for _, c := range cards {
+		if !validCard(c) || seen[c] {
+			return false
+		}
+		seen[c] = true
+	}
+	return true
+}
+
+func FuzzValue(f *testing.F) {
+	ht := NewHashTable()
+
+	f.Add([]byte{0, 1, 2, 3, 4})
+	f.Add([]byte{4, 8, 12, 16, 24})
+	f.Add([]byte{7, 7, 7, 7, 7})
+	f.Add([]byte{51, 52, 53, 200, 255})
+	f.Add([]byte{1, 2, 3})
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		cards := bytesToCards(data)
+		value := ht.Value(cards)
+
+		// Reversing and rotating the hand must not change its value
+		reversed := make([]card.Card, len(cards))
+		for i, c := range cards {
+			reversed[len(cards)-1-i] = c
+		}
+		if got := ht.Value(reversed); got != value {
+			t.Fatalf("Value not permutation invariant for %v: %d vs %d reversed", data, value, got)
+		}
+		if len(cards) > 1 {
+			rotated := append(append([]card.Card(nil), cards[1:]...), cards[0])
+			if got := ht.Value(rotated); got != value {
+				t.Fatalf("Value not permutation invariant for %v: %d vs %d rotated", data, value, got)
+			}
+		}
+
+		if len(cards) != handSize && value != HandValue(^uint64(0)) {
+			t.Fatalf("Expected max value for %d cards, got %d", len(cards), value)
+		}
+	})
+}
+
+func FuzzValueAgainstReference(f *testing.F) {
+	ht := NewHashTable()
+
+	f.Add([]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	f.Add([]byte{4, 8, 12, 16, 24, 5, 9, 13, 17, 25})
+	f.Add([]byte{0, 4, 8, 12, 48, 1, 5, 9, 13, 49})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) != 2*handSize {
+			return
+		}
+		a, b := bytesToCards(data[:handSize]), bytesToCards(data[handSize:])
+		if !distinctValidHand(a) || !distinctValidHand(b) {
+			return
+		}
+
+		va, vb := ht.Value(a), ht.Value(b)
+		got := 0
+		if va < vb {
+			got = -1
+		} else if va > vb {
+			got = 1
+		}
+
+		if want := reference.Compare(reference.DeuceSeven(a), reference.DeuceSeven(b)); got != want {
+			t.Fatalf("Comparison of %s (%d) and %s (%d) gave %d, reference gave %d",
+				formatCards(a), va, formatCards(b), vb, got, want)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package deucelowsingle
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank/internal/reference"
+)
+
+// forEachHand calls fn with every five-card hand from a 52-card deck
+func forEachHand(fn func(hand []card.Card)) {
+	hand := make([]card.Card, handSize)
+	var deal func(start, depth int)
+	deal = func(start, depth int) {
+		if depth == handSize {
+			fn(hand)
+			return
+		}
+		for i := start; i <= 52-(handSize-depth); i++ {
+			hand[depth] = card.Card(i)
+			deal(i+1, depth+1)
+		}
+	}
+	deal(0, 0)
+}
+
+func TestExhaustiveAgainstReference(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping exhaustive enumeration in short mode")
+	}
+	ht := NewHashTable()
+
+	type class struct {
+		key     reference.Key
+		example []card.Card
+	}
+	classes := make(map[HandValue]class)
+	hands := 0
+
+	forEachHand(func(hand []card.Card) {
+		hands++
+		value := ht.Value(hand)
+		key := reference.DeuceSeven(hand)
+
+		existing, ok := classes[value]
+		if !ok {
+			classes[value] = class{key, append([]card.Card(nil), hand...)}
+			return
+		}
+		if existing.key != key {
+			t.Fatalf("Hands with different reference ranks share value %d: %s and %s",
+				value, formatCards(existing.example), formatCards(hand))
+		}
+	})
+
+	if hands != 2598960 {
+		t.Fatalf("Expected 2,598,960 hands, enumerated %d", hands)
+	}
+
+	values := make([]HandValue, 0, len(classes))
+	for v := range classes {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	// Distinct values must be strictly better-to-worse under the reference
+	for i := 1; i < len(values); i++ {
+		prev, cur := classes[values[i-1]], classes[values[i]]
+		if reference.Compare(prev.key, cur.key) >= 0 {
+			t.Fatalf("Ordering mismatch: %s (value %d) should be worse than %s (value %d)",
+				formatCards(prev.example), values[i-1], formatCards(cur.example), values[i])
+		}
+	}
+
+	t.Logf("Distinct equivalence classes: %d", len(classes))
+	if len(classes) != 7462 {
+		t.Errorf("Expected 7462 equivalence classes, got %d", len(classes))
+	}
+}
@@ -0,0 +1,20 @@
+package deucelowsingle
+
+import (
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+)
+
+var _ handrank.Comparer = (*HashTable)(nil)
+
+// Compare returns 1 if hand a beats hand b at 2-7, -1 if it loses and 0 if
+// they tie. An invalid hand is valued as the worst possible hand, so it
+// loses to every valid hand.
+func (ht *HashTable) Compare(a, b []card.Card) int {
+	return handrank.Compare(ht, a, b)
+}
+
+// Showdown resolves a 2-7 showdown between hands in seat order
+func (ht *HashTable) Showdown(hands ...[]card.Card) handrank.ShowdownResult {
+	return handrank.Showdown(ht, hands...)
+}
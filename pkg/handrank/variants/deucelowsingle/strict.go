@@ -0,0 +1,66 @@
+package deucelowsingle
+
+import (
+	"fmt"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+// HandSizeError reports a hand that does not hold exactly five cards
+type HandSizeError struct {
+	Size int
+}
+
+func (e *HandSizeError) Error() string {
+	return fmt.Sprintf("deucelowsingle: hand has %d cards, want %d", e.Size, handSize)
+}
+
+// DuplicateCardError reports a card that appears more than once in a hand
+type DuplicateCardError struct {
+	Card card.Card
+}
+
+func (e *DuplicateCardError) Error() string {
+	return fmt.Sprintf("deucelowsingle: card %v appears more than once", e.Card)
+}
+
+// InvalidCardError reports a card code outside the 52-card deck
+type InvalidCardError struct {
+	Card card.Card
+}
+
+func (e *InvalidCardError) Error() string {
+	return fmt.Sprintf("deucelowsingle: invalid card code %d", int(e.Card))
+}
+
+// ValidateHand checks that cards is five distinct cards from a standard deck
+func ValidateHand(cards []card.Card) error {
+	if len(cards) != handSize {
+		return &HandSizeError{Size: len(cards)}
+	}
+
+	var seen uint64
+	for _, c := range cards {
+		if !validCard(c) {
+			return &InvalidCardError{Card: c}
+		}
+		bit := uint64(1) << uint(c)
+		if seen&bit != 0 {
+			return &DuplicateCardError{Card: c}
+		}
+		seen |= bit
+	}
+	return nil
+}
+
+// ValueStrict returns the value of a hand, or a typed error if the hand is
+// not five distinct valid cards. Value skips only the duplicate check and
+// reports other invalid hands with the max value instead of an error, and
+// ValueBatch skips every check, so callers that have already validated their
+// hands should keep using them.
+func (ht *HashTable) ValueStrict(cards []card.Card) (HandValue, error) {
+	if err := ValidateHand(cards); err != nil {
+		return 0, err
+	}
+	return ht.value(cards), nil
+}
@@ -0,0 +1,50 @@
+package deucelowsingle
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+func TestValueStrict(t *testing.T) {
+	ht := NewHashTable()
+	hand := makeHand([]cardSpec{{card.Two, card.Spades}, {card.Three, card.Hearts}, {card.Four, card.Diamonds},
+		{card.Five, card.Clubs}, {card.Seven, card.Spades}})
+
+	t.Run("Valid Hand", func(t *testing.T) {
+		value, err := ht.ValueStrict(hand)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if value != ht.Value(hand) {
+			t.Errorf("Strict value %d differs from Value %d", value, ht.Value(hand))
+		}
+	})
+
+	t.Run("Wrong Size", func(t *testing.T) {
+		_, err := ht.ValueStrict(hand[:4])
+		var sizeErr *HandSizeError
+		if !errors.As(err, &sizeErr) || sizeErr.Size != 4 {
+			t.Errorf("Expected HandSizeError for 4 cards, got %v", err)
+		}
+	})
+
+	t.Run("Duplicate Card", func(t *testing.T) {
+		dup := append([]card.Card{hand[0]}, hand[:4]...)
+		_, err := ht.ValueStrict(dup)
+		var dupErr *DuplicateCardError
+		if !errors.As(err, &dupErr) || dupErr.Card != hand[0] {
+			t.Errorf("Expected DuplicateCardError for %v, got %v", hand[0], err)
+		}
+	})
+
+	t.Run("Invalid Card", func(t *testing.T) {
+		bad := append([]card.Card{card.Card(60)}, hand[1:]...)
+		_, err := ht.ValueStrict(bad)
+		var cardErr *InvalidCardError
+		if !errors.As(err, &cardErr) {
+			t.Errorf("Expected InvalidCardError, got %v", err)
+		}
+	})
+}
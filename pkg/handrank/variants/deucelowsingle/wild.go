@@ -0,0 +1,125 @@
+package deucelowsingle
+
+import (
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+)
+
+// WildTable evaluates lowball hands in which some cards are wild. A wild
+// card plays as whichever card makes the best hand, which is always a card
+// that does not pair the hand and, in 2-7, never completes a straight when a
+// better card exists. A wild card can take any suit, so a hand holding one
+// is never a flush. Lower values are better.
+type WildTable struct {
+	base   handrank.Evaluator
+	deck   deck.Deck
+	isWild func(card.Card) bool
+
+	// wildTables[w] holds the best value of every rank multiset of the
+	// 5-w natural cards in a hand with w wild cards
+	wildTables [handSize + 1][]HandValue
+}
+
+// NewDeucesWildTable returns a 2-7 evaluator in which every deuce is wild
+func NewDeucesWildTable(ht *HashTable) *WildTable {
+	return newWildTable(ht, deck.Standard(),
+		func(c card.Card) bool { return c.Rank() == card.Two },
+		func(counts []uint8) HandValue { return ht.nonFlushTable[encodeRankCounts(counts)] })
+}
+
+// NewJokerAceFiveTable returns an A-5 evaluator for a 53-card deck in which
+// the joker is wild
+func NewJokerAceFiveTable(t *AceFiveTable) *WildTable {
+	return newWildTable(t, deck.Standard().WithJokers(1), deck.IsJoker, t.RankValue)
+}
+
+// newWildTable precomputes the best value for every split of a hand into
+// natural and wild cards, so wild hands cost a single lookup like any other
+func newWildTable(base handrank.Evaluator, d deck.Deck, isWild func(card.Card) bool,
+	rankValue func(counts []uint8) HandValue) *WildTable {
+	wt := &WildTable{base: base, deck: d, isWild: isWild}
+
+	counts := make([]uint8, 13)
+	for w := 1; w <= handSize; w++ {
+		table := make([]HandValue, handrank.RankMultisets(handSize-w))
+		var naturals func(pos, remaining int)
+		naturals = func(pos, remaining int) {
+			if remaining == 0 {
+				table[handrank.EncodeRankCounts(counts)] = bestCompletion(counts, 0, w, rankValue)
+				return
+			}
+			if pos >= 13 {
+				return
+			}
+			for n := 0; n <= min(4, remaining); n++ {
+				counts[pos] = uint8(n)
+				naturals(pos+1, remaining-n)
+			}
+			counts[pos] = 0
+		}
+		naturals(0, handSize-w)
+		wt.wildTables[w] = table
+	}
+	return wt
+}
+
+// bestCompletion tries every rank for the remaining wild cards, from pos
+// upwards, and returns the best value found
+func bestCompletion(counts []uint8, pos, wild int, rankValue func(counts []uint8) HandValue) HandValue {
+	if wild == 0 {
+		return rankValue(counts)
+	}
+	best := HandValue(^uint64(0))
+	for r := pos; r < 13; r++ {
+		// A rank that already holds four cards cannot take a fifth
+		if counts[r] == 4 {
+			continue
+		}
+		counts[r]++
+		if v := bestCompletion(counts, r, wild-1, rankValue); v < best {
+			best = v
+		}
+		counts[r]--
+	}
+	return best
+}
+
+// Rules describes the hands of the underlying lowball game
+func (wt *WildTable) Rules() handrank.GameRules {
+	return wt.base.Rules()
+}
+
+// Deck returns the cards the game is dealt from
+func (wt *WildTable) Deck() deck.Deck {
+	return wt.deck
+}
+
+// IsWild reports whether c plays as a wild card
+func (wt *WildTable) IsWild(c card.Card) bool {
+	return wt.isWild(c)
+}
+
+// Value returns the value of a hand with any wild cards played as their
+// best substitutes, or the max value for an invalid hand
+func (wt *WildTable) Value(cards []card.Card) HandValue {
+	if len(cards) != handSize {
+		return HandValue(^uint64(0))
+	}
+	wild := 0
+	var counts [13]uint8
+	for _, c := range cards {
+		if !wt.deck.Contains(c) {
+			return HandValue(^uint64(0))
+		}
+		if wt.isWild(c) {
+			wild++
+			continue
+		}
+		counts[c.Rank()]++
+	}
+	if wild == 0 {
+		return wt.base.Value(cards)
+	}
+	return wt.wildTables[wild][handrank.EncodeRankCounts(counts[:])]
+}
@@ -0,0 +1,132 @@
+package deucelowsingle
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+)
+
+// bruteWildValue tries every card for each wild card in the hand from
+// position start onwards
+func bruteWildValue(wt *WildTable, cards []card.Card, start int) HandValue {
+	for i := start; i < len(cards); i++ {
+		if !wt.IsWild(cards[i]) {
+			continue
+		}
+		best := HandValue(^uint64(0))
+		hand := append([]card.Card{}, cards...)
+		for sub := 0; sub < deck.StandardSize; sub++ {
+			hand[i] = card.Card(sub)
+			if v := bruteWildValue(wt, hand, i+1); v < best {
+				best = v
+			}
+		}
+		return best
+	}
+	return wt.base.Value(cards)
+}
+
+func TestDeucesWild(t *testing.T) {
+	wt := NewDeucesWildTable(NewHashTable())
+
+	hands := []struct {
+		wild, natural []cardSpec
+	}{
+		// The deuce plays as a deuce to make the nuts
+		{[]cardSpec{{card.Seven, card.Spades}, {card.Five, card.Hearts}, {card.Four, card.Diamonds},
+			{card.Three, card.Clubs}, {card.Two, card.Spades}},
+			[]cardSpec{{card.Seven, card.Spades}, {card.Five, card.Hearts}, {card.Four, card.Diamonds},
+				{card.Three, card.Clubs}, {card.Two, card.Hearts}}},
+		// Neither end of the straight is taken, so the deuce plays as an eight
+		{[]cardSpec{{card.Six, card.Spades}, {card.Five, card.Hearts}, {card.Four, card.Diamonds},
+			{card.Three, card.Clubs}, {card.Two, card.Spades}},
+			[]cardSpec{{card.Eight, card.Spades}, {card.Six, card.Hearts}, {card.Five, card.Diamonds},
+				{card.Four, card.Clubs}, {card.Three, card.Hearts}}},
+		// A suited hand does not play as a flush
+		{[]cardSpec{{card.Nine, card.Spades}, {card.Eight, card.Spades}, {card.Six, card.Spades},
+			{card.Four, card.Spades}, {card.Two, card.Spades}},
+			[]cardSpec{{card.Nine, card.Spades}, {card.Eight, card.Hearts}, {card.Six, card.Diamonds},
+				{card.Four, card.Clubs}, {card.Two, card.Hearts}}},
+		// Four deuces avoid pairing the lone card
+		{[]cardSpec{{card.King, card.Spades}, {card.Two, card.Spades}, {card.Two, card.Hearts},
+			{card.Two, card.Diamonds}, {card.Two, card.Clubs}},
+			[]cardSpec{{card.King, card.Spades}, {card.Five, card.Hearts}, {card.Four, card.Diamonds},
+				{card.Three, card.Clubs}, {card.Two, card.Hearts}}},
+	}
+	for _, h := range hands {
+		got, want := wt.Value(makeHand(h.wild)), wt.base.Value(makeHand(h.natural))
+		if got != want {
+			t.Errorf("Expected %v to play as %v (%d), got %d", makeHand(h.wild), makeHand(h.natural), want, got)
+		}
+	}
+
+	if v := wt.Value(makeHand([]cardSpec{{card.Seven, card.Spades}, {card.Five, card.Hearts},
+		{card.Four, card.Diamonds}, {card.Three, card.Clubs}})); v != HandValue(^uint64(0)) {
+		t.Errorf("Expected max value for a four-card hand, got %d", v)
+	}
+	if v := wt.Value(append(makeHand([]cardSpec{{card.Seven, card.Spades}, {card.Five, card.Hearts},
+		{card.Four, card.Diamonds}, {card.Three, card.Clubs}}), deck.Joker)); v != HandValue(^uint64(0)) {
+		t.Errorf("Expected max value for a joker in a 52-card game, got %d", v)
+	}
+}
+
+func TestJokerAceFive(t *testing.T) {
+	wt := NewJokerAceFiveTable(NewAceFiveTable())
+
+	wheel := append(makeHand([]cardSpec{{card.Ace, card.Spades}, {card.Two, card.Hearts},
+		{card.Three, card.Diamonds}, {card.Four, card.Clubs}}), deck.Joker)
+	natural := makeHand([]cardSpec{{card.Ace, card.Spades}, {card.Two, card.Hearts},
+		{card.Three, card.Diamonds}, {card.Four, card.Clubs}, {card.Five, card.Clubs}})
+	if got, want := wt.Value(wheel), wt.base.Value(natural); got != want {
+		t.Errorf("Expected the joker to complete the wheel (%d), got %d", want, got)
+	}
+
+	// With the ace through five already held, the joker plays as a six
+	// rather than pairing
+	sixLow := append(makeHand([]cardSpec{{card.Ace, card.Spades}, {card.Three, card.Hearts},
+		{card.Four, card.Diamonds}, {card.Five, card.Clubs}}), deck.Joker)
+	natural = makeHand([]cardSpec{{card.Ace, card.Spades}, {card.Three, card.Hearts},
+		{card.Four, card.Diamonds}, {card.Five, card.Clubs}, {card.Two, card.Clubs}})
+	if got, want := wt.Value(sixLow), wt.base.Value(natural); got != want {
+		t.Errorf("Expected the joker to play as a deuce (%d), got %d", want, got)
+	}
+	if !EightOrBetter(wt.Value(wheel)) {
+		t.Error("Expected a joker wheel to qualify for low")
+	}
+}
+
+func TestWildAgainstBruteForce(t *testing.T) {
+	tables := []*WildTable{
+		NewDeucesWildTable(NewHashTable()),
+		NewJokerAceFiveTable(NewAceFiveTable()),
+	}
+	rng := rand.New(rand.NewSource(37))
+	for _, wt := range tables {
+		cards := wt.Deck().Cards()
+		var wild, natural []card.Card
+		for _, c := range cards {
+			if wt.IsWild(c) {
+				wild = append(wild, c)
+			} else {
+				natural = append(natural, c)
+			}
+		}
+		for trial := 0; trial < 300; trial++ {
+			// Bias the deal towards wild cards so every wild count is covered
+			w := rng.Intn(len(wild) + 1)
+			rng.Shuffle(len(wild), func(i, j int) { wild[i], wild[j] = wild[j], wild[i] })
+			rng.Shuffle(len(natural), func(i, j int) { natural[i], natural[j] = natural[j], natural[i] })
+			hand := append(append([]card.Card{}, wild[:w]...), natural[:handSize-w]...)
+			// Four wild deuces take seven million substitutions; the
+			// table's four-wild case is checked in TestDeucesWild
+			if w > 3 || (w > 2 && testing.Short()) {
+				continue
+			}
+			if got, want := wt.Value(hand), bruteWildValue(wt, hand, 0); got != want {
+				t.Fatalf("Expected %v to value %d, got %d", hand, want, got)
+			}
+		}
+	}
+}
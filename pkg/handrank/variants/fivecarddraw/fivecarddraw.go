@@ -0,0 +1,28 @@
+package fivecarddraw
+
+import (
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/highhand"
+)
+
+// HashTable evaluates five-card draw high hands. Higher values are better,
+// and values can be classified with highhand.CategoryOf.
+type HashTable struct {
+	*highhand.HashTable
+}
+
+// NewHashTable builds the five-card high lookup tables
+func NewHashTable() *HashTable {
+	return &HashTable{HashTable: highhand.NewHashTable()}
+}
+
+// Rules describes five-card draw high hands
+func (ht *HashTable) Rules() handrank.GameRules {
+	return handrank.GameRules{
+		MaxCards:  5,
+		MinCards:  5,
+		UseSuits:  true,
+		HandSize:  5,
+		IsLowball: false,
+	}
+}
@@ -0,0 +1,261 @@
+package highhand
+
+import (
+	"math/bits"
+	"sort"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+)
+
+const (
+	// Category bonuses in ascending order of strength
+	pairBonus          = uint64(1000000)
+	twoPairBonus       = uint64(2000000)
+	tripsBonus         = uint64(3000000)
+	straightBonus      = uint64(4000000)
+	flushBonus         = uint64(5000000)
+	fullHouseBonus     = uint64(6000000)
+	quadsBonus         = uint64(7000000)
+	straightFlushBonus = uint64(8000000)
+
+	// CategorySize is the value span of one hand category
+	CategorySize = pairBonus
+
+	handSize = 5
+	deckSize = 52
+)
+
+// Category is the class of a high hand, from HighCard up to StraightFlush
+type Category int
+
+const (
+	HighCard Category = iota
+	Pair
+	TwoPair
+	Trips
+	Straight
+	Flush
+	FullHouse
+	Quads
+	StraightFlush
+)
+
+var categoryNames = [...]string{
+	"High Card", "Pair", "Two Pair", "Three of a Kind", "Straight",
+	"Flush", "Full House", "Four of a Kind", "Straight Flush",
+}
+
+func (c Category) String() string {
+	if c < HighCard || c > StraightFlush {
+		return "Unknown"
+	}
+	return categoryNames[c]
+}
+
+// CategoryOf returns the category of a value produced by a HashTable
+func CategoryOf(v handrank.HandValue) Category {
+	return Category(uint64(v) / CategorySize)
+}
+
+// HashTable evaluates five-card high hands. Higher values are better and 0
+// is returned for invalid hands.
+type HashTable struct {
+	flushTable    []handrank.HandValue
+	nonFlushTable []handrank.HandValue
+}
+
+// NewHashTable builds the flush and non-flush lookup tables
+func NewHashTable() *HashTable {
+	ht := &HashTable{
+		flushTable:    make([]handrank.HandValue, 8192),                             // 2^13 possible flush combinations
+		nonFlushTable: make([]handrank.HandValue, handrank.RankMultisets(handSize)), // All 5-card rank multisets
+	}
+
+	for binary := uint16(0); binary < 8192; binary++ {
+		if bits.OnesCount16(binary) == handSize {
+			ht.flushTable[binary] = calculateFlushValue(binary)
+		}
+	}
+
+	var generateCombinations func(pos, remaining int, ranks []uint8)
+	generateCombinations = func(pos, remaining int, ranks []uint8) {
+		if remaining == 0 {
+			ht.nonFlushTable[handrank.EncodeRankCounts(ranks)] = calculateNonFlushValue(ranks)
+			return
+		}
+		if pos >= 13 {
+			return
+		}
+		for count := 0; count <= min(4, remaining); count++ {
+			ranks[pos] = uint8(count)
+			generateCombinations(pos+1, remaining-count, ranks)
+			ranks[pos] = 0
+		}
+	}
+	generateCombinations(0, handSize, make([]uint8, 13))
+
+	return ht
+}
+
+// Rules describes five-card high hands
+func (ht *HashTable) Rules() handrank.GameRules {
+	return handrank.GameRules{
+		MaxCards: handSize,
+		MinCards: handSize,
+		UseSuits: true,
+		HandSize: handSize,
+	}
+}
+
+// Value returns the pre-computed value for a five-card hand, or 0 for
+// hands of the wrong size or with cards outside the deck
+func (ht *HashTable) Value(cards []card.Card) handrank.HandValue {
+	if len(cards) != handSize {
+		return 0
+	}
+	for _, c := range cards {
+		if int(c) >= deckSize {
+			return 0
+		}
+	}
+
+	suit := cards[0].Suit()
+	isFlush := true
+	for _, c := range cards[1:] {
+		if c.Suit() != suit {
+			isFlush = false
+			break
+		}
+	}
+
+	if isFlush {
+		var binary uint16
+		for _, c := range cards {
+			binary |= 1 << c.Rank()
+		}
+		return ht.flushTable[binary]
+	}
+
+	var counts [13]uint8
+	for _, c := range cards {
+		counts[c.Rank()]++
+	}
+	return ht.nonFlushTable[handrank.EncodeRankCounts(counts[:])]
+}
+
+// FlushValue returns the value of five distinct same-suited ranks given as a
+// 13-bit mask indexed by card rank
+func (ht *HashTable) FlushValue(binary uint16) handrank.HandValue {
+	return ht.flushTable[binary&0x1fff]
+}
+
+// NonFlushValue returns the value of five ranks given as per-rank counts
+// indexed by card rank, treating the cards as not all one suit
+func (ht *HashTable) NonFlushValue(counts []uint8) handrank.HandValue {
+	return ht.nonFlushTable[handrank.EncodeRankCounts(counts)]
+}
+
+// highRank maps a card rank to its high-hand strength, where the ace plays high
+func highRank(rank int) int {
+	if rank == 0 { // Ace
+		return 13
+	}
+	return rank
+}
+
+// getHandPattern returns the category bonus and ranks ordered by
+// significance: larger groups first, then higher ranks first
+func getHandPattern(ranks []uint8) (uint64, []int) {
+	rankList := make([]int, 0, handSize)
+	counts := make(map[int]int)
+	for i, count := range ranks {
+		for j := uint8(0); j < count; j++ {
+			rankList = append(rankList, highRank(i))
+			counts[highRank(i)]++
+		}
+	}
+
+	sort.Slice(rankList, func(i, j int) bool {
+		if counts[rankList[i]] != counts[rankList[j]] {
+			return counts[rankList[i]] > counts[rankList[j]]
+		}
+		return rankList[i] > rankList[j]
+	})
+
+	pairs, trips, quads := 0, 0, 0
+	for _, count := range counts {
+		switch count {
+		case 2:
+			pairs++
+		case 3:
+			trips++
+		case 4:
+			quads++
+		}
+	}
+
+	switch {
+	case quads > 0:
+		return quadsBonus, rankList
+	case trips > 0 && pairs > 0:
+		return fullHouseBonus, rankList
+	case trips > 0:
+		return tripsBonus, rankList
+	case pairs == 2:
+		return twoPairBonus, rankList
+	case pairs == 1:
+		return pairBonus, rankList
+	}
+
+	if straight, ok := straightRanks(rankList); ok {
+		return straightBonus, straight
+	}
+	return 0, rankList
+}
+
+// straightRanks reports whether five distinct ranks sorted high to low form a
+// straight, returning the ranks with a wheel's ace played low
+func straightRanks(rankList []int) ([]int, bool) {
+	if len(rankList) != handSize {
+		return nil, false
+	}
+	if rankList[0]-rankList[handSize-1] == handSize-1 {
+		return rankList, true
+	}
+	// A-5-4-3-2 plays as a five-high straight
+	if rankList[0] == 13 && rankList[1] == 4 && rankList[handSize-1] == 1 {
+		return []int{4, 3, 2, 1, 0}, true
+	}
+	return nil, false
+}
+
+// rankValue orders hands within a category, weighting the most significant
+// rank highest
+func rankValue(rankList []int) uint64 {
+	var value uint64
+	for _, rank := range rankList {
+		value = value*14 + uint64(rank)
+	}
+	return value
+}
+
+func calculateNonFlushValue(ranks []uint8) handrank.HandValue {
+	bonus, rankList := getHandPattern(ranks)
+	return handrank.HandValue(bonus + rankValue(rankList))
+}
+
+func calculateFlushValue(binary uint16) handrank.HandValue {
+	ranks := make([]uint8, 13)
+	for i := uint(0); i < 13; i++ {
+		if binary&(1<<i) != 0 {
+			ranks[i] = 1
+		}
+	}
+
+	bonus, rankList := getHandPattern(ranks)
+	if bonus == straightBonus {
+		return handrank.HandValue(straightFlushBonus + rankValue(rankList))
+	}
+	return handrank.HandValue(flushBonus + rankValue(rankList))
+}
@@ -0,0 +1,76 @@
+package highhand
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/internal/reference"
+)
+
+func TestExhaustiveAgainstReference(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping exhaustive enumeration in short mode")
+	}
+	ht := NewHashTable()
+
+	type class struct {
+		key     reference.Key
+		example []card.Card
+	}
+	classes := make(map[handrank.HandValue]class)
+
+	hand := make([]card.Card, handSize)
+	var deal func(start, depth int)
+	deal = func(start, depth int) {
+		if depth == handSize {
+			value := ht.Value(hand)
+			key := reference.High(hand)
+			if CategoryOf(value) != Category(key.Category) {
+				t.Fatalf("Hand %v has category %v, reference says %v", hand, CategoryOf(value), Category(key.Category))
+			}
+			if existing, ok := classes[value]; !ok {
+				classes[value] = class{key, append([]card.Card(nil), hand...)}
+			} else if existing.key != key {
+				t.Fatalf("Hands with different reference ranks share value %d: %v and %v",
+					value, existing.example, hand)
+			}
+			return
+		}
+		for i := start; i <= deckSize-(handSize-depth); i++ {
+			hand[depth] = card.Card(i)
+			deal(i+1, depth+1)
+		}
+	}
+	deal(0, 0)
+
+	values := make([]handrank.HandValue, 0, len(classes))
+	for v := range classes {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	// Higher values must be strictly better under the reference
+	for i := 1; i < len(values); i++ {
+		prev, cur := classes[values[i-1]], classes[values[i]]
+		if reference.Compare(cur.key, prev.key) <= 0 {
+			t.Fatalf("Ordering mismatch: %v (value %d) should beat %v (value %d)",
+				cur.example, values[i], prev.example, values[i-1])
+		}
+	}
+
+	if len(classes) != 7462 {
+		t.Errorf("Expected 7462 equivalence classes, got %d", len(classes))
+	}
+}
+
+func TestInvalidHands(t *testing.T) {
+	ht := NewHashTable()
+	if v := ht.Value([]card.Card{0, 1, 2, 3}); v != 0 {
+		t.Errorf("Expected 0 for a four card hand, got %d", v)
+	}
+	if v := ht.Value([]card.Card{0, 1, 2, 3, 60}); v != 0 {
+		t.Errorf("Expected 0 for an invalid card code, got %d", v)
+	}
+}
@@ -0,0 +1,107 @@
+package omaha
+
+import (
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+// HiLoValue is the result of evaluating an Omaha/8 hand. Low is only
+// meaningful when HasLow is set, and lower Low values are better.
+//
+// Deprecated: Use handrank.HiLoValue, which Stud/8 shares.
+type HiLoValue = handrank.HiLoValue
+
+// HiLoShowdown splits a pot among players still in the hand.
+//
+// Deprecated: Use handrank.HiLoShowdown.
+func HiLoShowdown(pot int, values []HiLoValue) []int {
+	return handrank.HiLoShowdown(pot, values)
+}
+
+// HiLoEvaluator scores Omaha Hi-Lo 8-or-better hands
+type HiLoEvaluator struct {
+	*Evaluator
+	aceFive *deucelowsingle.AceFiveTable
+
+	// lowTables holds, for each board size, the best A-5 value of a
+	// two-rank hole multiset played with any three cards of a board rank
+	// multiset, indexed like Evaluator.boardTables
+	lowTables [maxBoardCards + 1][]deucelowsingle.HandValue
+}
+
+// NewHiLoEvaluator builds the high tables and reduces the A-5 hole-by-triple
+// table over every board
+func NewHiLoEvaluator() *HiLoEvaluator {
+	e := &HiLoEvaluator{
+		Evaluator: NewEvaluator(),
+		aceFive:   deucelowsingle.NewAceFiveTable(),
+	}
+	worst := deucelowsingle.HandValue(^uint64(0))
+	tripleMultisets := handrank.RankMultisets(3)
+	lowTable := make([]deucelowsingle.HandValue, e.holeMultisets*tripleMultisets)
+	for i := range lowTable {
+		lowTable[i] = worst
+	}
+
+	// Only unpaired hands can qualify, so only distinct ranks are filled in
+	for h1 := 0; h1 < 13; h1++ {
+		for h2 := h1 + 1; h2 < 13; h2++ {
+			for b1 := 0; b1 < 13; b1++ {
+				for b2 := b1; b2 < 13; b2++ {
+					for b3 := b2; b3 < 13; b3++ {
+						var holeCounts, boardCounts, counts [13]uint8
+						holeCounts[h1]++
+						holeCounts[h2]++
+						boardCounts[b1]++
+						boardCounts[b2]++
+						boardCounts[b3]++
+						for r := range counts {
+							counts[r] = holeCounts[r] + boardCounts[r]
+						}
+						index := int(handrank.EncodeRankCounts(holeCounts[:]))*tripleMultisets +
+							int(handrank.EncodeRankCounts(boardCounts[:]))
+						lowTable[index] = e.aceFive.RankValue(counts[:])
+					}
+				}
+			}
+		}
+	}
+
+	lower := func(a, b handrank.HandValue) bool { return a < b }
+	for n := minBoardCards; n <= maxBoardCards; n++ {
+		e.lowTables[n] = e.boardTable(n, lowTable, worst, lower)
+	}
+	return e
+}
+
+// Evaluate returns the best high hand and the best qualifying A-5 low,
+// each using exactly two hole cards and three board cards
+func (e *HiLoEvaluator) Evaluate(hole, board []card.Card) handrank.HiLoValue {
+	result := handrank.HiLoValue{High: e.Value(hole, board)}
+	if result.High == 0 {
+		return result
+	}
+
+	var counts [13]uint8
+	for _, c := range board {
+		counts[c.Rank()]++
+	}
+	table := e.lowTables[len(board)]
+	boardIndex := int(handrank.EncodeRankCounts(counts[:])) * e.holeMultisets
+
+	best := deucelowsingle.HandValue(^uint64(0))
+	for _, p := range e.holePairs[len(hole)] {
+		var holeCounts [13]uint8
+		holeCounts[hole[p[0]].Rank()]++
+		holeCounts[hole[p[1]].Rank()]++
+		if v := table[boardIndex+int(handrank.EncodeRankCounts(holeCounts[:]))]; v < best {
+			best = v
+		}
+	}
+
+	if deucelowsingle.EightOrBetter(best) {
+		result.Low, result.HasLow = best, true
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+package omaha
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+// bruteForceLow evaluates every two-hole-card, three-board-card A-5 low
+func bruteForceLow(t5 *deucelowsingle.AceFiveTable, hole, board []card.Card) (deucelowsingle.HandValue, bool) {
+	best := deucelowsingle.HandValue(^uint64(0))
+	hand := make([]card.Card, 5)
+	for i := 0; i < len(hole); i++ {
+		for j := i + 1; j < len(hole); j++ {
+			for a := 0; a < len(board); a++ {
+				for b := a + 1; b < len(board); b++ {
+					for c := b + 1; c < len(board); c++ {
+						hand[0], hand[1] = hole[i], hole[j]
+						hand[2], hand[3], hand[4] = board[a], board[b], board[c]
+						if v := t5.Value(hand); v < best {
+							best = v
+						}
+					}
+				}
+			}
+		}
+	}
+	return best, deucelowsingle.EightOrBetter(best)
+}
+
+func TestHiLoAgainstBruteForce(t *testing.T) {
+	e := NewHiLoEvaluator()
+	rng := rand.New(rand.NewSource(2))
+
+	lows := 0
+	for i := 0; i < 50000; i++ {
+		holeSize := minHoleCards + rng.Intn(maxHoleCards-minHoleCards+1)
+		perm := rng.Perm(deckSize)
+		cards := make([]card.Card, holeSize+5)
+		for j := range cards {
+			cards[j] = card.Card(perm[j])
+		}
+		hole, board := cards[:holeSize], cards[holeSize:]
+
+		got := e.Evaluate(hole, board)
+		wantLow, wantHasLow := bruteForceLow(e.aceFive, hole, board)
+		if got.HasLow != wantHasLow || (wantHasLow && got.Low != wantLow) {
+			t.Fatalf("Hole %v board %v: got low %d (%v), brute force %d (%v)",
+				hole, board, got.Low, got.HasLow, wantLow, wantHasLow)
+		}
+		if got.High != bruteForceValue(e.high, hole, board) {
+			t.Fatalf("Hole %v board %v: high value mismatch", hole, board)
+		}
+		if got.HasLow {
+			lows++
+		}
+	}
+	if lows == 0 {
+		t.Error("Expected some random hands to make a qualifying low")
+	}
+}
+
+func TestHiLoTwoFromHandLow(t *testing.T) {
+	e := NewHiLoEvaluator()
+
+	// One low card in hand cannot make a low with a low board
+	hole := []card.Card{
+		card.NewCard(card.Spades, card.Ace), card.NewCard(card.Hearts, card.King),
+		card.NewCard(card.Diamonds, card.King), card.NewCard(card.Clubs, card.Queen),
+	}
+	board := []card.Card{
+		card.NewCard(card.Spades, card.Two), card.NewCard(card.Hearts, card.Three),
+		card.NewCard(card.Diamonds, card.Four), card.NewCard(card.Clubs, card.Five),
+		card.NewCard(card.Spades, card.Nine),
+	}
+	if v := e.Evaluate(hole, board); v.HasLow {
+		t.Errorf("Expected no low with a single low hole card, got %d", v.Low)
+	}
+
+	hole[1] = card.NewCard(card.Hearts, card.Two)
+	if v := e.Evaluate(hole, board); !v.HasLow {
+		t.Error("Expected A-2 in hand to make a low")
+	}
+}
+
+func TestHiLoShowdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		pot    int
+		values []HiLoValue
+		want   []int
+	}{
+		{
+			name:   "Scoop without a qualifying low",
+			pot:    101,
+			values: []HiLoValue{{High: 500}, {High: 400}},
+			want:   []int{101, 0},
+		},
+		{
+			name:   "Even split with odd chip to high",
+			pot:    101,
+			values: []HiLoValue{{High: 500}, {High: 400, Low: 10, HasLow: true}},
+			want:   []int{51, 50},
+		},
+		{
+			name:   "Scoop high and low",
+			pot:    100,
+			values: []HiLoValue{{High: 500, Low: 10, HasLow: true}, {High: 400, Low: 20, HasLow: true}},
+			want:   []int{100, 0},
+		},
+		{
+			name: "Quartered",
+			pot:  100,
+			values: []HiLoValue{
+				{High: 500, Low: 10, HasLow: true},
+				{High: 300, Low: 10, HasLow: true},
+				{High: 400},
+			},
+			want: []int{75, 25, 0},
+		},
+		{
+			name: "Odd chips to earliest seat",
+			pot:  103,
+			values: []HiLoValue{
+				{High: 300, Low: 10, HasLow: true},
+				{High: 500},
+				{High: 500},
+			},
+			want: []int{51, 26, 26},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HiLoShowdown(tt.pot, tt.values); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HiLoShowdown(%d) = %v, want %v", tt.pot, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+package omaha
+
+import (
+	"math/bits"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/highhand"
+)
+
+const (
+	minHoleCards  = 4
+	maxHoleCards  = 6
+	minBoardCards = 3
+	maxBoardCards = 5
+	deckSize      = 52
+)
+
+// pair and triple index into a hole hand and a board
+type pair [2]int
+type triple [3]int
+
+// holeRankPairs is the number of two-card hole hands of one suit, which have
+// two distinct ranks
+const holeRankPairs = 13 * 12 / 2
+
+// Evaluator scores Omaha high hands, where a player must use exactly two hole
+// cards and three board cards. Higher values are better. Its tables are
+// reduced over every board triple, so a hand costs one lookup per hole pair
+// for non-flush hands and one per suited hole pair for flushes.
+type Evaluator struct {
+	high *highhand.HashTable
+
+	// boardTables holds, for each board size, the best non-flush value of
+	// a two-rank hole multiset played with any three cards of a board rank
+	// multiset, indexed by boardIndex*holeMultisets + holeIndex
+	boardTables   [maxBoardCards + 1][]handrank.HandValue
+	holeMultisets int
+
+	// flushTable holds the best flush or straight flush of two suited hole
+	// ranks played with any three suited board ranks, indexed by
+	// flushRows[boardMask]*holeRankPairs + rankPairIndex. Only board masks
+	// of three to five ranks have rows.
+	flushTable []handrank.HandValue
+	flushRows  [1 << 13]int
+
+	holePairs    [maxHoleCards + 1][]pair
+	boardTriples [maxBoardCards + 1][]triple
+}
+
+// NewEvaluator builds the five-card tables and reduces them over every board
+func NewEvaluator() *Evaluator {
+	e := &Evaluator{
+		high:          highhand.NewHashTable(),
+		holeMultisets: handrank.RankMultisets(2),
+	}
+
+	for n := minHoleCards; n <= maxHoleCards; n++ {
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				e.holePairs[n] = append(e.holePairs[n], pair{i, j})
+			}
+		}
+	}
+	for n := minBoardCards; n <= maxBoardCards; n++ {
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				for k := j + 1; k < n; k++ {
+					e.boardTriples[n] = append(e.boardTriples[n], triple{i, j, k})
+				}
+			}
+		}
+	}
+
+	nonFlush := e.nonFlushTable()
+	higher := func(a, b handrank.HandValue) bool { return a > b }
+	for n := minBoardCards; n <= maxBoardCards; n++ {
+		e.boardTables[n] = e.boardTable(n, nonFlush, 0, higher)
+	}
+	e.buildFlushTable()
+	return e
+}
+
+// nonFlushTable returns the best non-flush value of every two-rank hole
+// multiset combined with every three-rank board multiset, indexed by
+// holeIndex*RankMultisets(3) + tripleIndex
+func (e *Evaluator) nonFlushTable() []handrank.HandValue {
+	tripleMultisets := handrank.RankMultisets(3)
+	table := make([]handrank.HandValue, e.holeMultisets*tripleMultisets)
+	for h1 := 0; h1 < 13; h1++ {
+		for h2 := h1; h2 < 13; h2++ {
+			for b1 := 0; b1 < 13; b1++ {
+				for b2 := b1; b2 < 13; b2++ {
+					for b3 := b2; b3 < 13; b3++ {
+						var holeCounts, boardCounts, counts [13]uint8
+						holeCounts[h1]++
+						holeCounts[h2]++
+						boardCounts[b1]++
+						boardCounts[b2]++
+						boardCounts[b3]++
+						for r := range counts {
+							counts[r] = holeCounts[r] + boardCounts[r]
+						}
+						// Five of a kind cannot be dealt and stays at 0
+						if counts[h1] > 4 {
+							continue
+						}
+						index := int(handrank.EncodeRankCounts(holeCounts[:]))*tripleMultisets +
+							int(handrank.EncodeRankCounts(boardCounts[:]))
+						table[index] = e.high.NonFlushValue(counts[:])
+					}
+				}
+			}
+		}
+	}
+	return table
+}
+
+// boardTable reduces a hole-by-triple table, indexed like nonFlushTable, to
+// the best value over the triples of every board rank multiset of size n.
+// Entries start at worst and are replaced by values better reports beating
+// them.
+func (e *Evaluator) boardTable(n int, combos []handrank.HandValue, worst handrank.HandValue,
+	better func(a, b handrank.HandValue) bool) []handrank.HandValue {
+	tripleMultisets := handrank.RankMultisets(3)
+	table := make([]handrank.HandValue, handrank.RankMultisets(n)*e.holeMultisets)
+	for i := range table {
+		table[i] = worst
+	}
+	ranks := make([]int, n)
+	var tripleIndexes [10]int
+	var fill func(i, from int)
+	fill = func(i, from int) {
+		if i < n {
+			for r := from; r < 13; r++ {
+				ranks[i] = r
+				fill(i+1, r)
+			}
+			return
+		}
+
+		var boardCounts [13]uint8
+		for _, r := range ranks {
+			boardCounts[r]++
+		}
+		for _, c := range boardCounts {
+			// Boards holding five of a rank cannot be dealt
+			if c > 4 {
+				return
+			}
+		}
+		boardIndex := int(handrank.EncodeRankCounts(boardCounts[:])) * e.holeMultisets
+
+		triples := e.boardTriples[n]
+		for j, t := range triples {
+			var counts [13]uint8
+			for _, idx := range t {
+				counts[ranks[idx]]++
+			}
+			tripleIndexes[j] = int(handrank.EncodeRankCounts(counts[:]))
+		}
+		for h1 := 0; h1 < 13; h1++ {
+			for h2 := h1; h2 < 13; h2++ {
+				var holeCounts [13]uint8
+				holeCounts[h1]++
+				holeCounts[h2]++
+				holeIndex := int(handrank.EncodeRankCounts(holeCounts[:]))
+				best := worst
+				for _, ti := range tripleIndexes[:len(triples)] {
+					if v := combos[holeIndex*tripleMultisets+ti]; better(v, best) {
+						best = v
+					}
+				}
+				table[boardIndex+holeIndex] = best
+			}
+		}
+	}
+	fill(0, 0)
+	return table
+}
+
+// buildFlushTable fills flushTable for every suited board of three to five
+// ranks and every pair of suited hole ranks not on that board
+func (e *Evaluator) buildFlushTable() {
+	rows := 0
+	for mask := 0; mask < 1<<13; mask++ {
+		if n := bits.OnesCount16(uint16(mask)); n >= minBoardCards && n <= maxBoardCards {
+			e.flushRows[mask] = rows
+			rows++
+		}
+	}
+	e.flushTable = make([]handrank.HandValue, rows*holeRankPairs)
+
+	for mask := 0; mask < 1<<13; mask++ {
+		n := bits.OnesCount16(uint16(mask))
+		if n < minBoardCards || n > maxBoardCards {
+			continue
+		}
+		var boardRanks []uint16
+		for r := 0; r < 13; r++ {
+			if mask&(1<<r) != 0 {
+				boardRanks = append(boardRanks, 1<<r)
+			}
+		}
+		row := e.flushRows[mask] * holeRankPairs
+		for r2 := 1; r2 < 13; r2++ {
+			for r1 := 0; r1 < r2; r1++ {
+				holeMask := uint16(1)<<r1 | uint16(1)<<r2
+				if holeMask&uint16(mask) != 0 {
+					continue
+				}
+				var best handrank.HandValue
+				for _, t := range e.boardTriples[n] {
+					v := e.high.FlushValue(holeMask | boardRanks[t[0]] | boardRanks[t[1]] | boardRanks[t[2]])
+					if v > best {
+						best = v
+					}
+				}
+				e.flushTable[row+rankPairIndex(r1, r2)] = best
+			}
+		}
+	}
+}
+
+// rankPairIndex returns a dense index below holeRankPairs for two distinct
+// ranks r1 < r2
+func rankPairIndex(r1, r2 int) int {
+	return r2*(r2-1)/2 + r1
+}
+
+// Rules describes Omaha hands with up to six hole cards and a full board
+func (e *Evaluator) Rules() handrank.GameRules {
+	return handrank.GameRules{
+		MaxCards: maxHoleCards + maxBoardCards,
+		MinCards: minHoleCards + minBoardCards,
+		UseSuits: true,
+		HandSize: 5,
+	}
+}
+
+// Hands is an Evaluator taking each hand as one slice of cards, the hole
+// cards followed by the board, so that it satisfies handrank.Evaluator
+type Hands struct {
+	*Evaluator
+	// HoleCards is the number of hole cards at the start of each hand
+	HoleCards int
+}
+
+var _ handrank.Evaluator = Hands{}
+
+// Value returns the best high hand of cards split into hole cards and board,
+// or 0 if either part is the wrong size
+func (h Hands) Value(cards []card.Card) handrank.HandValue {
+	if h.HoleCards > len(cards) {
+		return 0
+	}
+	return h.Evaluator.Value(cards[:h.HoleCards], cards[h.HoleCards:])
+}
+
+// Value returns the best high hand using exactly two of the 4-6 hole cards
+// and three of the 3-5 board cards. It returns 0 for hands or boards of the
+// wrong size or with cards outside the deck.
+func (e *Evaluator) Value(hole, board []card.Card) handrank.HandValue {
+	if len(hole) < minHoleCards || len(hole) > maxHoleCards ||
+		len(board) < minBoardCards || len(board) > maxBoardCards {
+		return 0
+	}
+	for _, cards := range [][]card.Card{hole, board} {
+		for _, c := range cards {
+			if int(c) >= deckSize {
+				return 0
+			}
+		}
+	}
+
+	var counts [13]uint8
+	for _, c := range board {
+		counts[c.Rank()]++
+	}
+	table := e.boardTables[len(board)]
+	boardIndex := int(handrank.EncodeRankCounts(counts[:])) * e.holeMultisets
+
+	var best handrank.HandValue
+	for _, p := range e.holePairs[len(hole)] {
+		var holeCounts [13]uint8
+		holeCounts[hole[p[0]].Rank()]++
+		holeCounts[hole[p[1]].Rank()]++
+		if v := table[boardIndex+int(handrank.EncodeRankCounts(holeCounts[:]))]; v > best {
+			best = v
+		}
+	}
+
+	if v := e.bestFlush(hole, board); v > best {
+		best = v
+	}
+	return best
+}
+
+// bestFlush returns the best flush or straight flush that uses two suited
+// hole cards and three board cards of the same suit, or 0 if there is none
+func (e *Evaluator) bestFlush(hole, board []card.Card) handrank.HandValue {
+	var best handrank.HandValue
+	for _, suit := range []card.Suit{card.Spades, card.Hearts, card.Diamonds, card.Clubs} {
+		holeRanks := suitedRanks(hole, suit)
+		var boardMask uint16
+		for _, r := range suitedRanks(board, suit) {
+			boardMask |= r
+		}
+		if len(holeRanks) < 2 || bits.OnesCount16(boardMask) < minBoardCards {
+			continue
+		}
+
+		row := e.flushRows[boardMask] * holeRankPairs
+		for i := 0; i < len(holeRanks); i++ {
+			for j := i + 1; j < len(holeRanks); j++ {
+				r1, r2 := bits.TrailingZeros16(holeRanks[i]), bits.TrailingZeros16(holeRanks[j])
+				if v := e.flushTable[row+rankPairIndex(min(r1, r2), max(r1, r2))]; v > best {
+					best = v
+				}
+			}
+		}
+	}
+	return best
+}
+
+// suitedRanks returns a one-bit rank mask for each card of the given suit
+func suitedRanks(cards []card.Card, suit card.Suit) []uint16 {
+	ranks := make([]uint16, 0, len(cards))
+	for _, c := range cards {
+		if c.Suit() == suit {
+			ranks = append(ranks, 1<<c.Rank())
+		}
+	}
+	return ranks
+}
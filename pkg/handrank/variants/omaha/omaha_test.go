@@ -0,0 +1,168 @@
+package omaha
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/highhand"
+)
+
+// bruteForceValue evaluates every two-hole-card, three-board-card combination
+func bruteForceValue(high *highhand.HashTable, hole, board []card.Card) handrank.HandValue {
+	var best handrank.HandValue
+	hand := make([]card.Card, 5)
+	for i := 0; i < len(hole); i++ {
+		for j := i + 1; j < len(hole); j++ {
+			for a := 0; a < len(board); a++ {
+				for b := a + 1; b < len(board); b++ {
+					for c := b + 1; c < len(board); c++ {
+						hand[0], hand[1] = hole[i], hole[j]
+						hand[2], hand[3], hand[4] = board[a], board[b], board[c]
+						if v := high.Value(hand); v > best {
+							best = v
+						}
+					}
+				}
+			}
+		}
+	}
+	return best
+}
+
+// remainingDeck returns the deck without the given cards
+func remainingDeck(used []card.Card) []card.Card {
+	skip := make(map[card.Card]bool)
+	for _, c := range used {
+		skip[c] = true
+	}
+	var deck []card.Card
+	for i := 0; i < deckSize; i++ {
+		if !skip[card.Card(i)] {
+			deck = append(deck, card.Card(i))
+		}
+	}
+	return deck
+}
+
+func TestExhaustiveFlopsAgainstBruteForce(t *testing.T) {
+	e := NewEvaluator()
+	holes := [][]card.Card{
+		// Double-suited aces
+		{card.NewCard(card.Spades, card.Ace), card.NewCard(card.Hearts, card.Ace),
+			card.NewCard(card.Spades, card.King), card.NewCard(card.Hearts, card.Queen)},
+		// Four of a suit cannot make more than one flush card pair
+		{card.NewCard(card.Clubs, card.Five), card.NewCard(card.Clubs, card.Six),
+			card.NewCard(card.Clubs, card.Seven), card.NewCard(card.Clubs, card.Eight), card.NewCard(card.Diamonds, card.Two)},
+		// Six-card Omaha with a wrap and a pair
+		{card.NewCard(card.Diamonds, card.Nine), card.NewCard(card.Hearts, card.Ten),
+			card.NewCard(card.Spades, card.Jack), card.NewCard(card.Clubs, card.Queen),
+			card.NewCard(card.Diamonds, card.Queen), card.NewCard(card.Hearts, card.Four)},
+	}
+
+	for _, hole := range holes {
+		deck := remainingDeck(hole)
+		board := make([]card.Card, 3)
+		for a := 0; a < len(deck); a++ {
+			for b := a + 1; b < len(deck); b++ {
+				for c := b + 1; c < len(deck); c++ {
+					board[0], board[1], board[2] = deck[a], deck[b], deck[c]
+					got, want := e.Value(hole, board), bruteForceValue(e.high, hole, board)
+					if got != want {
+						t.Fatalf("Hole %v board %v: got %d, brute force %d", hole, board, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRandomBoardsAgainstBruteForce(t *testing.T) {
+	e := NewEvaluator()
+	rng := rand.New(rand.NewSource(1))
+
+	trials := 200000
+	if testing.Short() {
+		trials = 20000
+	}
+	for i := 0; i < trials; i++ {
+		holeSize := minHoleCards + rng.Intn(maxHoleCards-minHoleCards+1)
+		boardSize := minBoardCards + rng.Intn(maxBoardCards-minBoardCards+1)
+		perm := rng.Perm(deckSize)
+		cards := make([]card.Card, holeSize+boardSize)
+		for j := range cards {
+			cards[j] = card.Card(perm[j])
+		}
+		hole, board := cards[:holeSize], cards[holeSize:]
+
+		got, want := e.Value(hole, board), bruteForceValue(e.high, hole, board)
+		if got != want {
+			t.Fatalf("Hole %v board %v: got %d, brute force %d", hole, board, got, want)
+		}
+	}
+}
+
+func TestTwoFromHandConstraint(t *testing.T) {
+	e := NewEvaluator()
+
+	// Four spades on board and one in hand is not a flush in Omaha
+	hole := []card.Card{
+		card.NewCard(card.Spades, card.Ace), card.NewCard(card.Hearts, card.King),
+		card.NewCard(card.Diamonds, card.Nine), card.NewCard(card.Clubs, card.Two),
+	}
+	board := []card.Card{
+		card.NewCard(card.Spades, card.Three), card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Spades, card.Jack), card.NewCard(card.Spades, card.Queen),
+		card.NewCard(card.Hearts, card.Four),
+	}
+	if c := highhand.CategoryOf(e.Value(hole, board)); c == highhand.Flush {
+		t.Errorf("One hole card made a flush with a four-flush board")
+	}
+
+	// A board straight with no hole help plays only two hole cards
+	hole = []card.Card{
+		card.NewCard(card.Spades, card.King), card.NewCard(card.Hearts, card.King),
+		card.NewCard(card.Diamonds, card.Two), card.NewCard(card.Clubs, card.Two),
+	}
+	board = []card.Card{
+		card.NewCard(card.Spades, card.Five), card.NewCard(card.Hearts, card.Six),
+		card.NewCard(card.Diamonds, card.Seven), card.NewCard(card.Clubs, card.Eight),
+		card.NewCard(card.Spades, card.Nine),
+	}
+	if c := highhand.CategoryOf(e.Value(hole, board)); c != highhand.Pair {
+		t.Errorf("Expected a pair using two hole cards, got %v", c)
+	}
+}
+
+func TestInvalidSizes(t *testing.T) {
+	e := NewEvaluator()
+	hole := []card.Card{0, 1, 2}
+	board := []card.Card{10, 11, 12}
+	if v := e.Value(hole, board); v != 0 {
+		t.Errorf("Expected 0 for a three card hole hand, got %d", v)
+	}
+	if v := e.Value(append(hole, 3), board[:2]); v != 0 {
+		t.Errorf("Expected 0 for a two card board, got %d", v)
+	}
+}
+
+func TestHands(t *testing.T) {
+	e := NewEvaluator()
+	hole := []card.Card{
+		card.NewCard(card.Spades, card.King), card.NewCard(card.Hearts, card.King),
+		card.NewCard(card.Diamonds, card.Two), card.NewCard(card.Clubs, card.Two),
+	}
+	board := []card.Card{
+		card.NewCard(card.Spades, card.Five), card.NewCard(card.Hearts, card.Six),
+		card.NewCard(card.Diamonds, card.Seven), card.NewCard(card.Clubs, card.Eight),
+		card.NewCard(card.Spades, card.Nine),
+	}
+	var h handrank.Evaluator = Hands{Evaluator: e, HoleCards: len(hole)}
+	if got, want := h.Value(append(append([]card.Card(nil), hole...), board...)), e.Value(hole, board); got != want {
+		t.Errorf("Expected %d for the hole cards then the board, got %d", want, got)
+	}
+	if v := h.Value(hole[:3]); v != 0 {
+		t.Errorf("Expected 0 for fewer cards than the hole, got %d", v)
+	}
+}
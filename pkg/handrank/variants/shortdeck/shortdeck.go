@@ -0,0 +1,298 @@
+package shortdeck
+
+import (
+	"math/bits"
+	"sort"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+)
+
+const (
+	// CategorySize is the value span of one hand category
+	CategorySize = uint64(1000000)
+
+	handSize   = 5
+	maxCards   = 7
+	lowestRank = 5 // card.Six; twos through fives are stripped from the deck
+
+	// strippedRanks masks the ranks two through five
+	strippedRanks = uint16(1<<lowestRank - 2)
+)
+
+// Category is the class of a short-deck hand
+type Category int
+
+const (
+	HighCard Category = iota
+	Pair
+	TwoPair
+	Trips
+	Straight
+	FullHouse
+	Flush
+	Quads
+	StraightFlush
+)
+
+var categoryNames = [...]string{
+	"High Card", "Pair", "Two Pair", "Three of a Kind", "Straight",
+	"Full House", "Flush", "Four of a Kind", "Straight Flush",
+}
+
+func (c Category) String() string {
+	if c < HighCard || c > StraightFlush {
+		return "Unknown"
+	}
+	return categoryNames[c]
+}
+
+// Options configures short-deck rules that differ between card rooms
+type Options struct {
+	// TripsBeatStraight ranks three of a kind above a straight, as many
+	// short-deck games do. By default a straight beats trips.
+	TripsBeatStraight bool
+}
+
+// HashTable evaluates short-deck hold'em hands of five to seven cards. With
+// 36 cards a flush is harder to make than a full house and ranks above it,
+// and A-6-7-8-9 plays as the lowest straight. Higher values are better and
+// 0 is returned for invalid hands.
+type HashTable struct {
+	opts          Options
+	flushTable    []handrank.HandValue
+	nonFlushTable []handrank.HandValue
+	combos        [maxCards + 1][][handSize]int
+}
+
+// NewHashTable builds the lookup tables for the given rules
+func NewHashTable(opts Options) *HashTable {
+	ht := &HashTable{
+		opts:          opts,
+		flushTable:    make([]handrank.HandValue, 8192),
+		nonFlushTable: make([]handrank.HandValue, handrank.RankMultisets(handSize)),
+	}
+
+	for binary := uint16(0); binary < 8192; binary++ {
+		if bits.OnesCount16(binary) == handSize && binary&strippedRanks == 0 {
+			ht.flushTable[binary] = ht.calculateValue(rankCounts(binary), true)
+		}
+	}
+
+	var generateCombinations func(pos, remaining int, ranks []uint8)
+	generateCombinations = func(pos, remaining int, ranks []uint8) {
+		if remaining == 0 {
+			ht.nonFlushTable[handrank.EncodeRankCounts(ranks)] = ht.calculateValue(ranks, false)
+			return
+		}
+		if pos >= 13 {
+			return
+		}
+		// Twos through fives are not in the deck
+		if pos > 0 && pos < lowestRank {
+			generateCombinations(lowestRank, remaining, ranks)
+			return
+		}
+		for count := 0; count <= min(4, remaining); count++ {
+			ranks[pos] = uint8(count)
+			generateCombinations(pos+1, remaining-count, ranks)
+			ranks[pos] = 0
+		}
+	}
+	generateCombinations(0, handSize, make([]uint8, 13))
+
+	for n := handSize; n <= maxCards; n++ {
+		var combo [handSize]int
+		var choose func(start, depth int)
+		choose = func(start, depth int) {
+			if depth == handSize {
+				ht.combos[n] = append(ht.combos[n], combo)
+				return
+			}
+			for i := start; i <= n-(handSize-depth); i++ {
+				combo[depth] = i
+				choose(i+1, depth+1)
+			}
+		}
+		choose(0, 0)
+	}
+
+	return ht
+}
+
+// Rules describes short-deck hold'em hands
+func (ht *HashTable) Rules() handrank.GameRules {
+	return handrank.GameRules{
+		MaxCards: maxCards,
+		MinCards: handSize,
+		UseSuits: true,
+		HandSize: handSize,
+	}
+}
+
+// shortDeck is built once, as InDeck is called for every card evaluated
+var shortDeck = deck.ShortDeck()
+
+// Deck returns the 36 cards from six through ace
+func (ht *HashTable) Deck() deck.Deck {
+	return shortDeck
+}
+
+// InDeck reports whether c is one of the 36 short-deck cards
+func InDeck(c card.Card) bool {
+	return shortDeck.Contains(c)
+}
+
+// Value returns the best five-card value from 5-7 cards, or 0 if there are
+// too few or too many cards or any card is not in the short deck
+func (ht *HashTable) Value(cards []card.Card) handrank.HandValue {
+	if len(cards) < handSize || len(cards) > maxCards {
+		return 0
+	}
+	for _, c := range cards {
+		if !InDeck(c) {
+			return 0
+		}
+	}
+
+	var best handrank.HandValue
+	for _, combo := range ht.combos[len(cards)] {
+		if v := ht.value5(cards, combo); v > best {
+			best = v
+		}
+	}
+	return best
+}
+
+// CategoryOf returns the category of a value produced by a HashTable
+func CategoryOf(v handrank.HandValue, opts Options) Category {
+	c := Category(uint64(v) / CategorySize)
+	if opts.TripsBeatStraight {
+		switch c {
+		case Trips:
+			return Straight
+		case Straight:
+			return Trips
+		}
+	}
+	return c
+}
+
+// value5 looks up five cards of a hand picked out by combo
+func (ht *HashTable) value5(cards []card.Card, combo [handSize]int) handrank.HandValue {
+	suit := cards[combo[0]].Suit()
+	isFlush := true
+	var binary uint16
+	var counts [13]uint8
+	for _, idx := range combo {
+		c := cards[idx]
+		if c.Suit() != suit {
+			isFlush = false
+		}
+		binary |= 1 << c.Rank()
+		counts[c.Rank()]++
+	}
+	if isFlush {
+		return ht.flushTable[binary]
+	}
+	return ht.nonFlushTable[handrank.EncodeRankCounts(counts[:])]
+}
+
+// rankCounts expands a 13-bit rank mask into per-rank counts
+func rankCounts(binary uint16) []uint8 {
+	ranks := make([]uint8, 13)
+	for i := uint(0); i < 13; i++ {
+		if binary&(1<<i) != 0 {
+			ranks[i] = 1
+		}
+	}
+	return ranks
+}
+
+// highRank maps a card rank to its strength, where the ace plays high
+func highRank(rank int) int {
+	if rank == 0 { // Ace
+		return 13
+	}
+	return rank
+}
+
+// calculateValue scores five ranks, encoding the category in the millions
+// and ordering ranks by significance below that
+func (ht *HashTable) calculateValue(ranks []uint8, flush bool) handrank.HandValue {
+	rankList := make([]int, 0, handSize)
+	counts := make(map[int]int)
+	for i, count := range ranks {
+		for j := uint8(0); j < count; j++ {
+			rankList = append(rankList, highRank(i))
+			counts[highRank(i)]++
+		}
+	}
+	sort.Slice(rankList, func(i, j int) bool {
+		if counts[rankList[i]] != counts[rankList[j]] {
+			return counts[rankList[i]] > counts[rankList[j]]
+		}
+		return rankList[i] > rankList[j]
+	})
+
+	pairs, trips, quads := 0, 0, 0
+	for _, count := range counts {
+		switch count {
+		case 2:
+			pairs++
+		case 3:
+			trips++
+		case 4:
+			quads++
+		}
+	}
+
+	straight := false
+	if len(counts) == handSize {
+		if rankList[0]-rankList[handSize-1] == handSize-1 {
+			straight = true
+		} else if rankList[0] == 13 && rankList[1] == highRank(lowestRank+3) && rankList[handSize-1] == highRank(lowestRank) {
+			// A-6-7-8-9 plays as a nine-high straight
+			straight = true
+			rankList = append(rankList[1:], lowestRank-1)
+		}
+	}
+
+	category := HighCard
+	switch {
+	case straight && flush:
+		category = StraightFlush
+	case quads > 0:
+		category = Quads
+	case flush:
+		category = Flush
+	case trips > 0 && pairs > 0:
+		category = FullHouse
+	case straight:
+		category = Straight
+	case trips > 0:
+		category = Trips
+	case pairs == 2:
+		category = TwoPair
+	case pairs == 1:
+		category = Pair
+	}
+
+	// Trips and straight swap value bands when trips rank higher
+	band := category
+	if ht.opts.TripsBeatStraight {
+		switch category {
+		case Trips:
+			band = Straight
+		case Straight:
+			band = Trips
+		}
+	}
+
+	var value uint64
+	for _, rank := range rankList {
+		value = value*14 + uint64(rank)
+	}
+	return handrank.HandValue(uint64(band)*CategorySize + value)
+}
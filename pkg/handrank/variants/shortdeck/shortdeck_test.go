@@ -0,0 +1,77 @@
+package shortdeck
+
+import (
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+func c(r card.Rank, s card.Suit) card.Card {
+	return card.NewCard(s, r)
+}
+
+func TestCategoryOrdering(t *testing.T) {
+	ht := NewHashTable(Options{})
+
+	flush := []card.Card{c(card.Six, card.Hearts), c(card.Eight, card.Hearts), c(card.Ten, card.Hearts),
+		c(card.Queen, card.Hearts), c(card.King, card.Hearts)}
+	fullHouse := []card.Card{c(card.Ace, card.Hearts), c(card.Ace, card.Spades), c(card.Ace, card.Clubs),
+		c(card.King, card.Spades), c(card.King, card.Clubs)}
+	if ht.Value(flush) <= ht.Value(fullHouse) {
+		t.Errorf("Expected a flush to beat a full house")
+	}
+	if got := CategoryOf(ht.Value(flush), Options{}); got != Flush {
+		t.Errorf("Expected Flush, got %v", got)
+	}
+
+	wheel := []card.Card{c(card.Ace, card.Hearts), c(card.Six, card.Spades), c(card.Seven, card.Clubs),
+		c(card.Eight, card.Diamonds), c(card.Nine, card.Clubs)}
+	sixHigh := []card.Card{c(card.Six, card.Hearts), c(card.Seven, card.Spades), c(card.Eight, card.Clubs),
+		c(card.Nine, card.Diamonds), c(card.Ten, card.Clubs)}
+	if got := CategoryOf(ht.Value(wheel), Options{}); got != Straight {
+		t.Fatalf("Expected A-6-7-8-9 to be a straight, got %v", got)
+	}
+	if ht.Value(wheel) >= ht.Value(sixHigh) {
+		t.Errorf("Expected A-6-7-8-9 to be the lowest straight")
+	}
+
+	trips := []card.Card{c(card.Ace, card.Hearts), c(card.Ace, card.Spades), c(card.Ace, card.Clubs),
+		c(card.Seven, card.Spades), c(card.Six, card.Clubs)}
+	if ht.Value(trips) >= ht.Value(wheel) {
+		t.Errorf("Expected a straight to beat trips by default")
+	}
+
+	tripsFirst := NewHashTable(Options{TripsBeatStraight: true})
+	if tripsFirst.Value(trips) <= tripsFirst.Value(sixHigh) {
+		t.Errorf("Expected trips to beat a straight with TripsBeatStraight")
+	}
+	if got := CategoryOf(tripsFirst.Value(trips), Options{TripsBeatStraight: true}); got != Trips {
+		t.Errorf("Expected Trips, got %v", got)
+	}
+}
+
+func TestSevenCardsAndDeck(t *testing.T) {
+	ht := NewHashTable(Options{})
+
+	// Hole cards plus board: the best five make a flush over a straight
+	cards := []card.Card{c(card.Jack, card.Spades), c(card.Ten, card.Spades), c(card.Nine, card.Spades),
+		c(card.Eight, card.Diamonds), c(card.Seven, card.Spades), c(card.Ace, card.Spades), c(card.Six, card.Clubs)}
+	if got := CategoryOf(ht.Value(cards), Options{}); got != Flush {
+		t.Errorf("Expected Flush, got %v", got)
+	}
+
+	deck := ht.Deck().Cards()
+	if len(deck) != 36 {
+		t.Fatalf("Expected 36 cards, got %d", len(deck))
+	}
+	for _, d := range deck {
+		if r := d.Rank(); r != card.Ace && r < card.Six {
+			t.Errorf("Unexpected card %v in short deck", d)
+		}
+	}
+
+	if v := ht.Value([]card.Card{c(card.Two, card.Spades), c(card.Six, card.Spades), c(card.Seven, card.Spades),
+		c(card.Eight, card.Spades), c(card.Nine, card.Hearts)}); v != 0 {
+		t.Errorf("Expected 0 for a hand with a deuce, got %d", v)
+	}
+}
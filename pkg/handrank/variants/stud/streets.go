@@ -0,0 +1,212 @@
+package stud
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+// Street is a stud betting round, named by how many cards each player holds
+type Street int
+
+const (
+	ThirdStreet   Street = 3
+	FourthStreet  Street = 4
+	FifthStreet   Street = 5
+	SixthStreet   Street = 6
+	SeventhStreet Street = 7
+)
+
+func (s Street) String() string {
+	switch s {
+	case ThirdStreet:
+		return "Third Street"
+	case FourthStreet:
+		return "Fourth Street"
+	case FifthStreet:
+		return "Fifth Street"
+	case SixthStreet:
+		return "Sixth Street"
+	case SeventhStreet:
+		return "Seventh Street"
+	}
+	return fmt.Sprintf("Street(%d)", int(s))
+}
+
+// IsDown reports whether the n-th card dealt to a player, counting from 0,
+// is dealt face down: the first two cards and the seventh street card
+func IsDown(n int) bool {
+	return n < 2 || n == maxCards-1
+}
+
+// DownCards returns how many of a player's cards are face down on a street
+func (s Street) DownCards() int {
+	down := 0
+	for n := 0; n < int(s); n++ {
+		if IsDown(n) {
+			down++
+		}
+	}
+	return down
+}
+
+// UpCards returns how many of a player's cards are face up on a street
+func (s Street) UpCards() int {
+	return int(s) - s.DownCards()
+}
+
+// Hand is one player's stud cards, split into face-down and face-up cards
+// in the order they were dealt
+type Hand struct {
+	Down []card.Card
+	Up   []card.Card
+}
+
+// Deal adds the next card to the hand, face down or face up according to
+// the street it arrives on
+func (h *Hand) Deal(c card.Card) error {
+	n := len(h.Down) + len(h.Up)
+	if n >= maxCards {
+		return fmt.Errorf("stud: hand already holds %d cards", maxCards)
+	}
+	if IsDown(n) {
+		h.Down = append(h.Down, c)
+	} else {
+		h.Up = append(h.Up, c)
+	}
+	return nil
+}
+
+// Street returns the street the hand has been dealt to
+func (h Hand) Street() Street {
+	return Street(len(h.Down) + len(h.Up))
+}
+
+// Cards returns every card in the hand, down cards first
+func (h Hand) Cards() []card.Card {
+	cards := make([]card.Card, 0, len(h.Down)+len(h.Up))
+	cards = append(cards, h.Down...)
+	return append(cards, h.Up...)
+}
+
+// DoorCard returns the first face-up card, or false before third street
+func (h Hand) DoorCard() (card.Card, bool) {
+	if len(h.Up) == 0 {
+		return 0, false
+	}
+	return h.Up[0], true
+}
+
+// suitOrder ranks suits for bring-in ties, clubs lowest and spades highest
+func suitOrder(s card.Suit) int {
+	switch s {
+	case card.Clubs:
+		return 0
+	case card.Diamonds:
+		return 1
+	case card.Hearts:
+		return 2
+	}
+	return 3
+}
+
+// doorStrength orders door cards for the bring-in, higher being stronger.
+// Aces play high in stud and low in razz.
+func doorStrength(game Game, c card.Card) int {
+	rank := int(c.Rank())
+	if rank == 0 && game != Razz {
+		rank = 13
+	}
+	return rank*4 + suitOrder(c.Suit())
+}
+
+// BringIn returns the seat that must bring in on third street: the lowest
+// door card in stud and stud hi-lo, the highest in razz, with suits breaking
+// ties. It returns -1 if no door cards are given.
+func BringIn(game Game, doors []card.Card) int {
+	seat := -1
+	for i, c := range doors {
+		if seat == -1 {
+			seat = i
+			continue
+		}
+		s, best := doorStrength(game, c), doorStrength(game, doors[seat])
+		if (game == Razz && s > best) || (game != Razz && s < best) {
+			seat = i
+		}
+	}
+	return seat
+}
+
+// FirstToAct returns the seat that acts first from fourth street on: the best
+// high hand showing in stud and stud hi-lo, the best low showing in razz.
+// Ties go to the earliest seat. It returns -1 if no hands are given.
+func FirstToAct(game Game, up [][]card.Card) int {
+	seat := -1
+	var best []int
+	for i, cards := range up {
+		key := showingKey(game, cards)
+		if seat == -1 || compareKeys(key, best) > 0 {
+			seat, best = i, key
+		}
+	}
+	return seat
+}
+
+// showingKey orders exposed cards so that a larger key is the better showing
+// hand. Only pairs, trips and quads count; straights and flushes are not
+// considered on board.
+func showingKey(game Game, cards []card.Card) []int {
+	counts := make(map[int]int)
+	ranks := make([]int, 0, len(cards))
+	for _, c := range cards {
+		rank := int(c.Rank())
+		if rank == 0 && game != Razz {
+			rank = 13
+		}
+		counts[rank]++
+		ranks = append(ranks, rank)
+	}
+
+	if game == Razz {
+		// Unpaired low cards are best: fewer pairs first, then the lowest
+		// cards from the top down
+		sort.Sort(sort.Reverse(sort.IntSlice(ranks)))
+		key := []int{-(len(cards) - len(counts))}
+		for _, r := range ranks {
+			key = append(key, -r)
+		}
+		return key
+	}
+
+	sort.Slice(ranks, func(i, j int) bool {
+		if counts[ranks[i]] != counts[ranks[j]] {
+			return counts[ranks[i]] > counts[ranks[j]]
+		}
+		return ranks[i] > ranks[j]
+	})
+	groups := make([]int, 0, len(counts))
+	for _, n := range counts {
+		groups = append(groups, n)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(groups)))
+	key := append([]int{}, groups...)
+	for len(key) < maxCards {
+		key = append(key, 0)
+	}
+	return append(key, ranks...)
+}
+
+// compareKeys compares two showing keys lexicographically
+func compareKeys(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] > b[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+	return len(a) - len(b)
+}
@@ -0,0 +1,172 @@
+package stud
+
+import (
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/highhand"
+)
+
+const (
+	handSize = 5
+	maxCards = 7
+	deckSize = 52
+)
+
+// Game is a seven-card stud variant
+type Game int
+
+const (
+	// StudHigh plays for the best high hand
+	StudHigh Game = iota
+	// StudHiLo splits the pot between the best high hand and the best
+	// 8-or-better A-5 low
+	StudHiLo
+	// Razz plays for the best A-5 low with no qualifier
+	Razz
+)
+
+func (g Game) String() string {
+	switch g {
+	case StudHigh:
+		return "Stud"
+	case StudHiLo:
+		return "Stud Hi-Lo"
+	case Razz:
+		return "Razz"
+	}
+	return "Unknown"
+}
+
+// Evaluator picks the best five of up to seven stud cards
+type Evaluator struct {
+	high    *highhand.HashTable
+	aceFive *deucelowsingle.AceFiveTable
+
+	// combos lists the five-card index subsets of a hand of each size
+	combos [maxCards + 1][][handSize]int
+}
+
+// NewEvaluator builds the high and A-5 tables
+func NewEvaluator() *Evaluator {
+	e := &Evaluator{
+		high:    highhand.NewHashTable(),
+		aceFive: deucelowsingle.NewAceFiveTable(),
+	}
+
+	for n := handSize; n <= maxCards; n++ {
+		var combo [handSize]int
+		var choose func(start, depth int)
+		choose = func(start, depth int) {
+			if depth == handSize {
+				e.combos[n] = append(e.combos[n], combo)
+				return
+			}
+			for i := start; i <= n-(handSize-depth); i++ {
+				combo[depth] = i
+				choose(i+1, depth+1)
+			}
+		}
+		choose(0, 0)
+	}
+	return e
+}
+
+// Rules describes stud hands of five to seven cards for a game
+func (e *Evaluator) Rules(game Game) handrank.GameRules {
+	return handrank.GameRules{
+		MaxCards:  maxCards,
+		MinCards:  handSize,
+		UseSuits:  game != Razz,
+		HandSize:  handSize,
+		IsLowball: game == Razz,
+	}
+}
+
+// High returns the best five-card high value from 5-7 cards, or 0 for an
+// invalid number of cards
+func (e *Evaluator) High(cards []card.Card) handrank.HandValue {
+	if len(cards) < handSize || len(cards) > maxCards {
+		return 0
+	}
+	var best handrank.HandValue
+	hand := make([]card.Card, handSize)
+	for _, combo := range e.combos[len(cards)] {
+		for i, idx := range combo {
+			hand[i] = cards[idx]
+		}
+		if v := e.high.Value(hand); v > best {
+			best = v
+		}
+	}
+	return best
+}
+
+// Low returns the best five-card A-5 value from 5-7 cards, or the max value
+// for an invalid number of cards. Lower values are better.
+func (e *Evaluator) Low(cards []card.Card) handrank.HandValue {
+	best := handrank.HandValue(^uint64(0))
+	if len(cards) < handSize || len(cards) > maxCards {
+		return best
+	}
+	hand := make([]card.Card, handSize)
+	for _, combo := range e.combos[len(cards)] {
+		for i, idx := range combo {
+			hand[i] = cards[idx]
+		}
+		if v := e.aceFive.Value(hand); v < best {
+			best = v
+		}
+	}
+	return best
+}
+
+// HiLo returns the best high hand and best 8-or-better low from 5-7 cards
+func (e *Evaluator) HiLo(cards []card.Card) handrank.HiLoValue {
+	result := handrank.HiLoValue{High: e.High(cards)}
+	if low := e.Low(cards); deucelowsingle.EightOrBetter(low) {
+		result.Low, result.HasLow = low, true
+	}
+	return result
+}
+
+// Showdown evaluates each player's cards for a game and returns the
+// fraction of the pot each one wins
+func (e *Evaluator) Showdown(game Game, hands [][]card.Card) []float64 {
+	shares := make([]float64, len(hands))
+	if len(hands) == 0 {
+		return shares
+	}
+
+	values := make([]handrank.HiLoValue, len(hands))
+	for i, cards := range hands {
+		switch game {
+		case Razz:
+			// Score razz as a low that always qualifies and no high
+			values[i] = handrank.HiLoValue{Low: e.Low(cards), HasLow: true}
+		case StudHiLo:
+			values[i] = e.HiLo(cards)
+		default:
+			values[i] = handrank.HiLoValue{High: e.High(cards)}
+		}
+	}
+
+	highWinners, lowWinners := handrank.HiLoWinners(values)
+	if game == Razz {
+		highWinners = nil
+	}
+	highShare, lowShare := 1.0, 0.0
+	switch {
+	case game == Razz:
+		highShare, lowShare = 0, 1
+	case len(lowWinners) > 0:
+		highShare, lowShare = 0.5, 0.5
+	}
+	for _, w := range highWinners {
+		shares[w] += highShare / float64(len(highWinners))
+	}
+	for _, w := range lowWinners {
+		shares[w] += lowShare / float64(len(lowWinners))
+	}
+	return shares
+}
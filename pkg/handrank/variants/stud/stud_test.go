@@ -0,0 +1,109 @@
+package stud
+
+import (
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/highhand"
+)
+
+func c(r card.Rank, s card.Suit) card.Card {
+	return card.NewCard(s, r)
+}
+
+func TestBestFiveOfSeven(t *testing.T) {
+	e := NewEvaluator()
+
+	// A flush hidden among seven cards with a pair on board
+	cards := []card.Card{
+		c(card.Two, card.Hearts), c(card.Nine, card.Hearts), c(card.King, card.Clubs), c(card.King, card.Spades),
+		c(card.Jack, card.Hearts), c(card.Four, card.Hearts), c(card.Six, card.Hearts),
+	}
+	if got := highhand.CategoryOf(e.High(cards)); got != highhand.Flush {
+		t.Errorf("Expected a flush, got %v", got)
+	}
+
+	// Razz takes the best low and ignores the pair
+	razz := []card.Card{
+		c(card.Ace, card.Hearts), c(card.Ace, card.Spades), c(card.Two, card.Clubs), c(card.Three, card.Spades),
+		c(card.King, card.Hearts), c(card.Seven, card.Diamonds), c(card.Five, card.Hearts),
+	}
+	want := e.aceFive.Value([]card.Card{razz[0], razz[2], razz[3], razz[5], razz[6]})
+	if got := e.Low(razz); got != want {
+		t.Errorf("Expected razz low %d (7-5-3-2-A), got %d", want, got)
+	}
+
+	hilo := e.HiLo(razz)
+	if !hilo.HasLow || hilo.Low != want {
+		t.Errorf("Expected qualifying 7-low in stud hi-lo, got %+v", hilo)
+	}
+	if got := highhand.CategoryOf(hilo.High); got != highhand.Pair {
+		t.Errorf("Expected a pair of aces for high, got %v", got)
+	}
+}
+
+func TestShowdown(t *testing.T) {
+	e := NewEvaluator()
+	wheel := []card.Card{
+		c(card.Ace, card.Hearts), c(card.Two, card.Spades), c(card.Three, card.Clubs), c(card.Four, card.Spades),
+		c(card.Five, card.Hearts), c(card.King, card.Diamonds), c(card.Queen, card.Hearts),
+	}
+	trips := []card.Card{
+		c(card.Nine, card.Hearts), c(card.Nine, card.Spades), c(card.Nine, card.Clubs), c(card.Jack, card.Spades),
+		c(card.Ten, card.Hearts), c(card.Four, card.Diamonds), c(card.Two, card.Clubs),
+	}
+
+	if got := e.Showdown(StudHigh, [][]card.Card{wheel, trips}); got[0] != 1 || got[1] != 0 {
+		t.Errorf("Expected the wheel straight to scoop in stud, got %v", got)
+	}
+	if got := e.Showdown(StudHiLo, [][]card.Card{wheel, trips}); got[0] != 1 || got[1] != 0 {
+		t.Errorf("Expected the wheel to scoop high and low in stud hi-lo, got %v", got)
+	}
+	if got := e.Showdown(Razz, [][]card.Card{trips, wheel}); got[0] != 0 || got[1] != 1 {
+		t.Errorf("Expected the wheel to win razz, got %v", got)
+	}
+}
+
+func TestStreets(t *testing.T) {
+	var h Hand
+	for i := 0; i < 7; i++ {
+		if err := h.Deal(card.Card(i)); err != nil {
+			t.Fatalf("Unexpected error dealing card %d: %v", i, err)
+		}
+		street := h.Street()
+		if len(h.Down) != street.DownCards() || len(h.Up) != street.UpCards() {
+			t.Errorf("%v: got %d down and %d up", street, len(h.Down), len(h.Up))
+		}
+	}
+	if h.Street() != SeventhStreet || len(h.Down) != 3 || len(h.Up) != 4 {
+		t.Errorf("Expected 3 down and 4 up on seventh street, got %d and %d", len(h.Down), len(h.Up))
+	}
+	if door, ok := h.DoorCard(); !ok || door != card.Card(2) {
+		t.Errorf("Expected the third card dealt as door card, got %v", door)
+	}
+	if err := h.Deal(card.Card(8)); err == nil {
+		t.Error("Expected an error dealing an eighth card")
+	}
+}
+
+func TestBringInAndFirstToAct(t *testing.T) {
+	doors := []card.Card{c(card.Two, card.Spades), c(card.King, card.Hearts), c(card.Two, card.Clubs), c(card.Ace, card.Diamonds)}
+	if got := BringIn(StudHigh, doors); got != 2 {
+		t.Errorf("Expected the deuce of clubs to bring in, got seat %d", got)
+	}
+	if got := BringIn(Razz, doors); got != 1 {
+		t.Errorf("Expected the king to bring in at razz, got seat %d", got)
+	}
+
+	up := [][]card.Card{
+		{c(card.King, card.Hearts), c(card.Queen, card.Hearts)},
+		{c(card.Four, card.Spades), c(card.Four, card.Clubs)},
+		{c(card.Ace, card.Spades), c(card.Three, card.Clubs)},
+	}
+	if got := FirstToAct(StudHigh, up); got != 1 {
+		t.Errorf("Expected the pair of fours to act first, got seat %d", got)
+	}
+	if got := FirstToAct(Razz, up); got != 2 {
+		t.Errorf("Expected 3-A to act first at razz, got seat %d", got)
+	}
+}
@@ -0,0 +1,28 @@
+package report
+
+import (
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+var rankNames = [...]string{"A", "2", "3", "4", "5", "6", "7", "8", "9", "T", "J", "Q", "K"}
+
+// DeuceSevenLabel names a 2-7 value the way players group hands: unpaired
+// hands by their top card, such as "8-low" or "A-high", and the rest by
+// category
+func DeuceSevenLabel(v handrank.HandValue) string {
+	if deucelowsingle.CategoryOf(v) != deucelowsingle.HighCard {
+		return deucelowsingle.CategoryOf(v).String()
+	}
+	top := deucelowsingle.Ranks(v)[0]
+	if top == card.Ace {
+		return "A-high"
+	}
+	return rankNames[top] + "-low"
+}
+
+// DeuceSevenCategory names a 2-7 value by its category alone
+func DeuceSevenCategory(v handrank.HandValue) string {
+	return deucelowsingle.CategoryOf(v).String()
+}
@@ -0,0 +1,171 @@
+// Package report renders draw outcome distributions for the terminal: bar
+// charts, tables of outcomes grouped by category and cumulative curves.
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dgunzy/hand-eval/pkg/drawsim"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+)
+
+// Style chooses the characters bars are drawn with
+type Style int
+
+const (
+	// Unicode draws bars with block characters in eighths of a cell
+	Unicode Style = iota
+	// ASCII draws bars with '#', for terminals without Unicode
+	ASCII
+)
+
+// eighths are the Unicode blocks for one to seven eighths of a cell
+var eighths = []rune("▏▎▍▌▋▊▉")
+
+// Options set the look of a report
+type Options struct {
+	Style Style
+	// Width is the length in cells of a full bar
+	Width int
+}
+
+// DefaultOptions draws Unicode bars 40 cells long
+func DefaultOptions() Options {
+	return Options{Style: Unicode, Width: 40}
+}
+
+// Bar is one labelled value of a bar chart
+type Bar struct {
+	Label string
+	Value float64
+}
+
+// Bar returns a bar of fraction, from 0 to 1, of a full bar's width. A
+// negative width draws no bar.
+func (o Options) Bar(fraction float64) string {
+	fraction = math.Max(0, math.Min(1, fraction))
+	width := float64(max(o.Width, 0))
+	if o.Style == ASCII {
+		return strings.Repeat("#", int(math.Round(fraction*width)))
+	}
+	cells := fraction * width
+	full := int(cells)
+	s := strings.Repeat("█", full)
+	if part := int((cells - float64(full)) * 8); part > 0 {
+		s += string(eighths[part-1])
+	}
+	return s
+}
+
+// BarChart writes one line per bar, scaled so that the largest value fills
+// the width
+func BarChart(w io.Writer, bars []Bar, o Options) error {
+	var top float64
+	labelWidth := 0
+	for _, b := range bars {
+		top = math.Max(top, b.Value)
+		labelWidth = max(labelWidth, utf8.RuneCountInString(b.Label))
+	}
+	for _, b := range bars {
+		fraction := 0.0
+		if top > 0 {
+			fraction = b.Value / top
+		}
+		line := fmt.Sprintf("%s  %s", pad(b.Label, labelWidth), o.Bar(fraction))
+		if _, err := fmt.Fprintf(w, "%s %g\n", strings.TrimRight(line, " "), b.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Group is the outcomes of a distribution sharing a label
+type Group struct {
+	Label string
+	Count uint64
+	// Fraction is the group's share of all outcomes
+	Fraction float64
+	// Cumulative is the share of outcomes in this group or a better one
+	Cumulative float64
+}
+
+// Groups collects the outcomes of d under label, best first. Values whose
+// labels are equal are expected to be contiguous in the distribution's
+// order, as categories and low cards are; a label that recurs after another
+// starts a new group.
+func Groups(d *drawsim.Distribution, label func(handrank.HandValue) string) []Group {
+	var groups []Group
+	var seen uint64
+	for _, v := range d.Values() {
+		l := label(v)
+		if len(groups) == 0 || groups[len(groups)-1].Label != l {
+			groups = append(groups, Group{Label: l})
+		}
+		n := d.Count(v)
+		seen += n
+		g := &groups[len(groups)-1]
+		g.Count += n
+		if d.Total() > 0 {
+			g.Fraction = float64(g.Count) / float64(d.Total())
+			g.Cumulative = float64(seen) / float64(d.Total())
+		}
+	}
+	return groups
+}
+
+// CategoryTable writes the outcomes of d grouped by label, with each group's
+// count, share and a bar scaled to the largest group
+func CategoryTable(w io.Writer, d *drawsim.Distribution, label func(handrank.HandValue) string, o Options) error {
+	groups := Groups(d, label)
+	labelWidth := len("Outcome")
+	var top float64
+	for _, g := range groups {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(g.Label))
+		top = math.Max(top, g.Fraction)
+	}
+	if _, err := fmt.Fprintf(w, "%s  %10s  %7s\n", pad("Outcome", labelWidth), "Count", "Share"); err != nil {
+		return err
+	}
+	for _, g := range groups {
+		fraction := 0.0
+		if top > 0 {
+			fraction = g.Fraction / top
+		}
+		line := fmt.Sprintf("%s  %10d  %6.2f%%  %s", pad(g.Label, labelWidth), g.Count, g.Fraction*100, o.Bar(fraction))
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CumulativeCurve writes the chance of finishing with each group of label or
+// better, such as "P(≤ 8-low)", with bars on an absolute scale
+func CumulativeCurve(w io.Writer, d *drawsim.Distribution, label func(handrank.HandValue) string, o Options) error {
+	groups := Groups(d, label)
+	labels := make([]string, len(groups))
+	labelWidth := 0
+	for i, g := range groups {
+		labels[i] = "P(≤ " + g.Label + ")"
+		labelWidth = max(labelWidth, utf8.RuneCountInString(labels[i]))
+	}
+	for i, g := range groups {
+		line := fmt.Sprintf("%s  %6.2f%%  %s", pad(labels[i], labelWidth), g.Cumulative*100, o.Bar(g.Cumulative))
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pad right-pads s with spaces to width runes
+func pad(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
@@ -0,0 +1,78 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/drawsim"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+func TestBar(t *testing.T) {
+	tests := []struct {
+		opts     Options
+		fraction float64
+		want     string
+	}{
+		{Options{Style: ASCII, Width: 10}, 0.5, "#####"},
+		{Options{Style: ASCII, Width: 10}, 2, "##########"},
+		{Options{Style: Unicode, Width: 4}, 0.5, "██"},
+		{Options{Style: Unicode, Width: 4}, 0.5625, "██▎"},
+		{Options{Style: Unicode, Width: 4}, 0, ""},
+		{Options{Style: Unicode, Width: -1}, 1, ""},
+		{Options{Style: ASCII, Width: -1}, 1, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.opts.Bar(tt.fraction); got != tt.want {
+			t.Errorf("Expected bar %q for %.4f, got %q", tt.want, tt.fraction, got)
+		}
+	}
+}
+
+func TestReports(t *testing.T) {
+	ht := deucelowsingle.NewHashTable()
+	kept := []card.Card{
+		card.NewCard(card.Clubs, card.Eight),
+		card.NewCard(card.Diamonds, card.Seven),
+		card.NewCard(card.Hearts, card.Six),
+		card.NewCard(card.Spades, card.Three),
+	}
+	d, err := drawsim.NewSimulator(kept, nil, 1, drawsim.WithEvaluator(ht)).Enumerate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	groups := Groups(d, DeuceSevenLabel)
+	if len(groups) != 8 || groups[0].Label != "8-low" || groups[0].Count != 12 ||
+		groups[6].Label != "A-high" || groups[7].Label != "Pair" || groups[7].Cumulative != 1 {
+		t.Fatalf("Unexpected groups %+v", groups)
+	}
+
+	opts := Options{Style: ASCII, Width: 12}
+	var buf bytes.Buffer
+	if err := CategoryTable(&buf, d, DeuceSevenLabel, opts); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if lines[1] != "8-low            12   25.00%  ############" || lines[2] != "9-low             4    8.33%  ####" {
+		t.Errorf("Unexpected table:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := CumulativeCurve(&buf, d, DeuceSevenLabel, opts); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if lines := strings.Split(buf.String(), "\n"); lines[1] != "P(≤ 9-low)    33.33%  ####" {
+		t.Errorf("Unexpected curve:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := BarChart(&buf, []Bar{{"a", 2}, {"bb", 1}}, Options{Style: ASCII, Width: 4}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if buf.String() != "a   #### 2\nbb  ## 1\n" {
+		t.Errorf("Unexpected chart %q", buf.String())
+	}
+}
@@ -0,0 +1,258 @@
+// Package solver finds approximate equilibrium strategies for heads-up 2-7
+// single draw with counterfactual regret minimisation over a hand
+// abstraction.
+package solver
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+	"github.com/dgunzy/hand-eval/pkg/drawsim"
+	"github.com/dgunzy/hand-eval/pkg/handrank"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+const handSize = 5
+
+// Class groups starting hands that play alike before the draw
+type Class int
+
+const (
+	Pat7 Class = iota
+	Pat8
+	Pat9
+	PatTen
+	OneTo7
+	OneTo8
+	TwoTo7
+	TwoTo8
+	Rough
+	NumClasses
+)
+
+var classNames = [...]string{
+	"Pat 7", "Pat 8", "Pat 9", "Pat 10", "One to a 7", "One to an 8",
+	"Two to a 7", "Two to an 8", "Rough",
+}
+
+func (c Class) String() string {
+	if c < Pat7 || c >= NumClasses {
+		return "Unknown"
+	}
+	return classNames[c]
+}
+
+// Classify returns the class of a five-card starting hand: a made ten-low
+// or better by its top card, otherwise the best one- or two-card draw to a
+// seven or eight, otherwise Rough
+func Classify(ht *deucelowsingle.HashTable, hand []card.Card) Class {
+	v := ht.Value(hand)
+	if deucelowsingle.CategoryOf(v) == deucelowsingle.HighCard {
+		switch top := deucelowsingle.Ranks(v)[0]; {
+		case lowballRank(top) <= lowballRank(card.Seven):
+			return Pat7
+		case top == card.Eight:
+			return Pat8
+		case top == card.Nine:
+			return Pat9
+		case top == card.Ten:
+			return PatTen
+		}
+	}
+
+	for _, draw := range []struct {
+		kept     int
+		to7, to8 Class
+	}{{4, OneTo7, OneTo8}, {3, TwoTo7, TwoTo8}} {
+		kept := KeepBest(hand, draw.kept)
+		if !distinctRanks(kept) {
+			continue
+		}
+		switch top := lowballRank(kept[len(kept)-1].Rank()); {
+		case top <= lowballRank(card.Seven):
+			return draw.to7
+		case top <= lowballRank(card.Eight):
+			return draw.to8
+		}
+	}
+	return Rough
+}
+
+// KeepBest returns the k cards a 2-7 player keeps from a hand: the lowest
+// cards of distinct ranks, ordered low to high, with paired cards kept only
+// once every unpaired rank is used
+func KeepBest(hand []card.Card, k int) []card.Card {
+	sorted := append([]card.Card(nil), hand...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return lowballRank(sorted[i].Rank()) < lowballRank(sorted[j].Rank())
+	})
+
+	kept := make([]card.Card, 0, k)
+	var extra []card.Card
+	seen := make(map[card.Rank]bool)
+	for _, c := range sorted {
+		if seen[c.Rank()] {
+			extra = append(extra, c)
+			continue
+		}
+		seen[c.Rank()] = true
+		kept = append(kept, c)
+	}
+	kept = append(kept, extra...)
+	return kept[:k]
+}
+
+func distinctRanks(cards []card.Card) bool {
+	seen := make(map[card.Rank]bool)
+	for _, c := range cards {
+		if seen[c.Rank()] {
+			return false
+		}
+		seen[c.Rank()] = true
+	}
+	return true
+}
+
+// lowballRank orders ranks for 2-7, where the ace plays high
+func lowballRank(r card.Rank) int {
+	if r == card.Ace {
+		return 13
+	}
+	return int(r)
+}
+
+// Bucket groups final hands that are close in strength. Lower buckets are
+// better; hands in the same bucket are treated as splitting the pot.
+type Bucket int
+
+// NoBucket marks an information set before the draw
+const NoBucket Bucket = -1
+
+// NumBuckets is the number of final hand buckets
+const NumBuckets = 15
+
+// BucketOf returns the bucket of a 2-7 value: sevens, eights and nines by
+// their top two cards, ten through ace high by their top card, and every
+// pair, straight or flush in the last bucket
+func BucketOf(v handrank.HandValue) Bucket {
+	if deucelowsingle.CategoryOf(v) != deucelowsingle.HighCard {
+		return NumBuckets - 1
+	}
+	ranks := deucelowsingle.Ranks(v)
+	top, second := lowballRank(ranks[0]), lowballRank(ranks[1])
+	switch {
+	case top <= lowballRank(card.Seven):
+		return Bucket(second - lowballRank(card.Five))
+	case top == lowballRank(card.Eight):
+		return Bucket(2 + second - lowballRank(card.Five))
+	case top == lowballRank(card.Nine):
+		return Bucket(5 + second - lowballRank(card.Five))
+	}
+	return Bucket(9 + top - lowballRank(card.Ten))
+}
+
+// Abstraction holds how often each class is dealt and the distribution of
+// final buckets for each class and draw count
+type Abstraction struct {
+	DrawOptions []int
+	// ClassFreq is the probability of being dealt each class
+	ClassFreq [NumClasses]float64
+	// Transitions[c][i][b] is the probability that a hand of class c
+	// drawing DrawOptions[i] cards finishes in bucket b
+	Transitions [NumClasses][][NumBuckets]float64
+}
+
+// NewAbstraction deals samples random starting hands, and for each one and
+// each draw count keeps the best cards and simulates trials draws with
+// drawsim. Card removal between the two players is not modelled.
+func NewAbstraction(ht *deucelowsingle.HashTable, drawOptions []int, samples, trials int, rng *rand.Rand) (*Abstraction, error) {
+	a := &Abstraction{DrawOptions: drawOptions}
+	for c := range a.Transitions {
+		a.Transitions[c] = make([][NumBuckets]float64, len(drawOptions))
+	}
+
+	cards := deck.Standard().Cards()
+	var counts [NumClasses]int
+	for s := 0; s < samples; s++ {
+		for i := 0; i < handSize; i++ {
+			j := i + rng.Intn(len(cards)-i)
+			cards[i], cards[j] = cards[j], cards[i]
+		}
+		hand := cards[:handSize]
+		class := Classify(ht, hand)
+		counts[class]++
+
+		for i, draw := range drawOptions {
+			kept := KeepBest(hand, handSize-draw)
+			if draw == 0 {
+				a.Transitions[class][i][BucketOf(ht.Value(kept))] += float64(trials)
+				continue
+			}
+			dead := discards(hand, kept)
+			results, err := drawsim.NewSimulator(kept, dead, draw, drawsim.WithEvaluator(ht)).RunSimulation(trials)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range results {
+				a.Transitions[class][i][BucketOf(r.HandValue)]++
+			}
+		}
+	}
+
+	for c := range a.Transitions {
+		a.ClassFreq[c] = float64(counts[c]) / float64(samples)
+		for i := range a.Transitions[c] {
+			if counts[c] == 0 {
+				// An unseen class never occurs, but give it a valid
+				// distribution so sampling stays well defined
+				a.Transitions[c][i][NumBuckets-1] = 1
+				continue
+			}
+			for b := range a.Transitions[c][i] {
+				a.Transitions[c][i][b] /= float64(counts[c] * trials)
+			}
+		}
+	}
+	return a, nil
+}
+
+// discards returns the cards of hand that are not kept
+func discards(hand, kept []card.Card) []card.Card {
+	var out []card.Card
+	for _, c := range hand {
+		found := false
+		for _, k := range kept {
+			if c == k {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// sampleClass draws a class according to ClassFreq
+func (a *Abstraction) sampleClass(rng *rand.Rand) Class {
+	return Class(sample(a.ClassFreq[:], rng))
+}
+
+// sampleBucket draws a final bucket for a class and draw option index
+func (a *Abstraction) sampleBucket(c Class, option int, rng *rand.Rand) Bucket {
+	return Bucket(sample(a.Transitions[c][option][:], rng))
+}
+
+func sample(probs []float64, rng *rand.Rand) int {
+	x := rng.Float64()
+	for i, p := range probs {
+		if x < p {
+			return i
+		}
+		x -= p
+	}
+	return len(probs) - 1
+}
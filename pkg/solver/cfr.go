@@ -0,0 +1,294 @@
+package solver
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+// Betting actions. A betting round allows one bet, which can be called or
+// folded to; there are no raises.
+const (
+	Check = 'c'
+	Bet   = 'b'
+	Fold  = 'f'
+	Call  = 'k'
+)
+
+// Config sets the abstraction and game sizes. Player 0 is out of position:
+// they act first in each betting round and draw first.
+type Config struct {
+	// Ante is what each player puts in the pot before the deal
+	Ante float64
+	// PreDrawBet and PostDrawBet are the bet sizes in each round
+	PreDrawBet  float64
+	PostDrawBet float64
+	// DrawOptions lists the draw counts a player may choose
+	DrawOptions []int
+	// Samples starting hands with Trials draws each estimate the transitions
+	Samples int
+	Trials  int
+	// Iterations of chance-sampled CFR to run
+	Iterations int
+	Seed       int64
+}
+
+// DefaultConfig returns a limit-style game with a small bet before the draw,
+// a big bet after it, and draws of zero to three cards
+func DefaultConfig() Config {
+	return Config{
+		Ante:        1,
+		PreDrawBet:  1,
+		PostDrawBet: 2,
+		DrawOptions: []int{0, 1, 2, 3},
+		Samples:     5000,
+		Trials:      20,
+		Iterations:  200000,
+		Seed:        1,
+	}
+}
+
+// InfoSet identifies what a player knows when acting. PreDraw and PostDraw
+// are the betting in each round so far; Draws holds the draw option indexes
+// chosen, or -1 where a player has not drawn yet.
+type InfoSet struct {
+	Player   int
+	Class    Class
+	Bucket   Bucket
+	PreDraw  string
+	Draws    [2]int
+	PostDraw string
+}
+
+func (k InfoSet) String() string {
+	return fmt.Sprintf("P%d %v b%d [%s] %v [%s]", k.Player, k.Class, k.Bucket, k.PreDraw, k.Draws, k.PostDraw)
+}
+
+// node accumulates regrets and the average strategy at one information set
+type node struct {
+	regrets     []float64
+	strategySum []float64
+}
+
+// strategy returns the current regret-matching strategy
+func (n *node) strategy() []float64 {
+	s := make([]float64, len(n.regrets))
+	var total float64
+	for i, r := range n.regrets {
+		if r > 0 {
+			s[i] = r
+			total += r
+		}
+	}
+	for i := range s {
+		if total > 0 {
+			s[i] /= total
+		} else {
+			s[i] = 1 / float64(len(s))
+		}
+	}
+	return s
+}
+
+// average returns the average strategy over all iterations
+func (n *node) average() []float64 {
+	s := make([]float64, len(n.strategySum))
+	var total float64
+	for _, v := range n.strategySum {
+		total += v
+	}
+	for i, v := range n.strategySum {
+		if total > 0 {
+			s[i] = v / total
+		} else {
+			s[i] = 1 / float64(len(s))
+		}
+	}
+	return s
+}
+
+// Solver runs CFR over the abstracted game
+type Solver struct {
+	cfg   Config
+	abs   *Abstraction
+	nodes map[InfoSet]*node
+	rng   *rand.Rand
+}
+
+// NewSolver builds the abstraction for cfg, using ht for every showdown and
+// draw simulation
+func NewSolver(ht *deucelowsingle.HashTable, cfg Config) (*Solver, error) {
+	if len(cfg.DrawOptions) == 0 {
+		return nil, fmt.Errorf("solver: no draw options")
+	}
+	for _, d := range cfg.DrawOptions {
+		if d < 0 || d > handSize {
+			return nil, fmt.Errorf("solver: invalid draw count %d", d)
+		}
+	}
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	abs, err := NewAbstraction(ht, cfg.DrawOptions, cfg.Samples, cfg.Trials, rng)
+	if err != nil {
+		return nil, err
+	}
+	return &Solver{cfg: cfg, abs: abs, nodes: make(map[InfoSet]*node), rng: rng}, nil
+}
+
+// Abstraction returns the hand abstraction the solver plays on
+func (s *Solver) Abstraction() *Abstraction {
+	return s.abs
+}
+
+// Solve runs the configured number of iterations and returns the average
+// strategies. It can be called again to continue refining them.
+func (s *Solver) Solve() *Solution {
+	for i := 0; i < s.cfg.Iterations; i++ {
+		d := &deal{classes: [2]Class{s.abs.sampleClass(s.rng), s.abs.sampleClass(s.rng)}}
+		s.walk(d, state{draws: [2]int{-1, -1}}, 1, 1)
+	}
+
+	sol := &Solution{cfg: s.cfg, abs: s.abs, strategies: make(map[InfoSet][]float64, len(s.nodes))}
+	for k, n := range s.nodes {
+		sol.strategies[k] = n.average()
+	}
+	return sol
+}
+
+// deal is the chance outcome of one iteration
+type deal struct {
+	classes [2]Class
+}
+
+// state is the public history of a hand
+type state struct {
+	pre, post string
+	draws     [2]int
+	buckets   [2]Bucket
+}
+
+// roundOver reports whether a betting round ended with both players in
+func roundOver(r string) bool {
+	return r == "cc" || r == "bk" || r == "cbk"
+}
+
+// bettingActions returns the actions available and the player to act in a
+// betting round that is still open
+func bettingActions(r string) ([]byte, int) {
+	actor := len(r) % 2
+	if len(r) > 0 && r[len(r)-1] == Bet {
+		return []byte{Fold, Call}, actor
+	}
+	return []byte{Check, Bet}, actor
+}
+
+// contributions returns what each player has put in the pot
+func (s *Solver) contributions(st state) [2]float64 {
+	c := [2]float64{s.cfg.Ante, s.cfg.Ante}
+	for _, r := range []struct {
+		history string
+		size    float64
+	}{{st.pre, s.cfg.PreDrawBet}, {st.post, s.cfg.PostDrawBet}} {
+		for i := 0; i < len(r.history); i++ {
+			if a := r.history[i]; a == Bet || a == Call {
+				c[i%2] += r.size
+			}
+		}
+	}
+	return c
+}
+
+// walk traverses the game from st and returns player 0's expected utility.
+// r0 and r1 are each player's probability of reaching st.
+func (s *Solver) walk(d *deal, st state, r0, r1 float64) float64 {
+	round := &st.pre
+	if st.draws[1] >= 0 {
+		round = &st.post
+	}
+
+	// Terminal states
+	if n := len(*round); n > 0 && (*round)[n-1] == Fold {
+		c := s.contributions(st)
+		if (n-1)%2 == 0 {
+			return -c[0]
+		}
+		return c[1]
+	}
+	if roundOver(st.post) {
+		c := s.contributions(st)
+		var equity float64
+		switch {
+		case st.buckets[0] < st.buckets[1]:
+			equity = 1
+		case st.buckets[0] == st.buckets[1]:
+			equity = 0.5
+		}
+		return (2*equity - 1) * c[0]
+	}
+
+	var actions []byte
+	var actor int
+	drawing := roundOver(st.pre) && st.draws[1] < 0
+	if drawing {
+		actor = 0
+		if st.draws[0] >= 0 {
+			actor = 1
+		}
+	} else {
+		actions, actor = bettingActions(*round)
+	}
+
+	key := InfoSet{Player: actor, Class: d.classes[actor], Bucket: NoBucket, PreDraw: st.pre, Draws: st.draws, PostDraw: st.post}
+	if !drawing && st.draws[1] >= 0 {
+		key.Bucket = st.buckets[actor]
+	}
+	if drawing && actor == 0 {
+		// Player 0 draws before seeing player 1's draw
+		key.Draws = [2]int{-1, -1}
+	}
+	count := len(actions)
+	if drawing {
+		count = len(s.cfg.DrawOptions)
+	}
+	n := s.nodes[key]
+	if n == nil {
+		n = &node{regrets: make([]float64, count), strategySum: make([]float64, count)}
+		s.nodes[key] = n
+	}
+
+	strategy := n.strategy()
+	utils := make([]float64, count)
+	var nodeUtil float64
+	for i := range strategy {
+		next := st
+		if drawing {
+			next.draws[actor] = i
+			if actor == 1 {
+				// Both draws are in: deal the final hands
+				next.buckets[0] = s.abs.sampleBucket(d.classes[0], next.draws[0], s.rng)
+				next.buckets[1] = s.abs.sampleBucket(d.classes[1], i, s.rng)
+			}
+		} else if st.draws[1] >= 0 {
+			next.post += string(actions[i])
+		} else {
+			next.pre += string(actions[i])
+		}
+
+		if actor == 0 {
+			utils[i] = s.walk(d, next, r0*strategy[i], r1)
+		} else {
+			utils[i] = s.walk(d, next, r0, r1*strategy[i])
+		}
+		nodeUtil += strategy[i] * utils[i]
+	}
+
+	own, opp, sign := r0, r1, 1.0
+	if actor == 1 {
+		own, opp, sign = r1, r0, -1
+	}
+	for i := range strategy {
+		n.regrets[i] += opp * sign * (utils[i] - nodeUtil)
+		n.strategySum[i] += own * strategy[i]
+	}
+	return nodeUtil
+}
@@ -0,0 +1,158 @@
+package solver
+
+import "strconv"
+
+// Solution holds the average strategy found at every information set
+type Solution struct {
+	cfg        Config
+	abs        *Abstraction
+	strategies map[InfoSet][]float64
+}
+
+// Strategy returns the action probabilities at an information set, in the
+// order of Actions, or false if the set was never reached
+func (s *Solution) Strategy(k InfoSet) ([]float64, bool) {
+	st, ok := s.strategies[k]
+	return st, ok
+}
+
+// Actions returns the action labels at an information set: draw counts
+// while drawing, otherwise the betting actions
+func (s *Solution) Actions(k InfoSet) []string {
+	if roundOver(k.PreDraw) && k.Bucket == NoBucket {
+		labels := make([]string, len(s.cfg.DrawOptions))
+		for i, d := range s.cfg.DrawOptions {
+			labels[i] = drawLabel(d)
+		}
+		return labels
+	}
+	round := k.PreDraw
+	if k.Bucket != NoBucket {
+		round = k.PostDraw
+	}
+	actions, _ := bettingActions(round)
+	labels := make([]string, len(actions))
+	for i, a := range actions {
+		labels[i] = actionNames[a]
+	}
+	return labels
+}
+
+var actionNames = map[byte]string{Check: "check", Bet: "bet", Fold: "fold", Call: "call"}
+
+func drawLabel(d int) string {
+	if d == 0 {
+		return "pat"
+	}
+	return "draw " + strconv.Itoa(d)
+}
+
+// InfoSets returns the number of information sets in the solution
+func (s *Solution) InfoSets() int {
+	return len(s.strategies)
+}
+
+// ClassStrategy summarises how a player plays a class before and during the
+// draw, averaged over the lines that reach each decision
+type ClassStrategy struct {
+	Player int
+	Class  Class
+	// Bet is how often the player bets when first able to: player 0 on
+	// opening the action, player 1 when checked to
+	Bet float64
+	// Call is how often the player calls a pre-draw bet
+	Call float64
+	// Draws is how often each of Config.DrawOptions is chosen, weighted by
+	// how likely each pre-draw line and opposing draw is
+	Draws []float64
+}
+
+// ClassStrategies returns a summary for each player and class
+func (s *Solution) ClassStrategies() []ClassStrategy {
+	var out []ClassStrategy
+	for player := 0; player < 2; player++ {
+		for c := Class(0); c < NumClasses; c++ {
+			cs := ClassStrategy{Player: player, Class: c, Draws: make([]float64, len(s.cfg.DrawOptions))}
+			open, facing := "", "b"
+			if player == 0 {
+				facing = "cb"
+			} else {
+				open = "c"
+			}
+			cs.Bet = s.prob(InfoSet{Player: player, Class: c, Bucket: NoBucket, PreDraw: open, Draws: [2]int{-1, -1}}, 1)
+			cs.Call = s.prob(InfoSet{Player: player, Class: c, Bucket: NoBucket, PreDraw: facing, Draws: [2]int{-1, -1}}, 1)
+			out = append(out, cs)
+		}
+	}
+
+	// Weight each draw decision by the probability of reaching it, summed
+	// over both players' classes
+	var weights [2][NumClasses]float64
+	for c0 := Class(0); c0 < NumClasses; c0++ {
+		for c1 := Class(0); c1 < NumClasses; c1++ {
+			p := s.abs.ClassFreq[c0] * s.abs.ClassFreq[c1]
+			if p == 0 {
+				continue
+			}
+			s.accumulateDraws(out, &weights, [2]Class{c0, c1}, "", p)
+		}
+	}
+	for i := range out {
+		if w := weights[out[i].Player][out[i].Class]; w > 0 {
+			for j := range out[i].Draws {
+				out[i].Draws[j] /= w
+			}
+		}
+	}
+	return out
+}
+
+// accumulateDraws follows the pre-draw betting from history pre, adding each
+// player's draw strategy weighted by the probability p of the line
+func (s *Solution) accumulateDraws(out []ClassStrategy, weights *[2][NumClasses]float64, classes [2]Class, pre string, p float64) {
+	if roundOver(pre) {
+		k0 := InfoSet{Player: 0, Class: classes[0], Bucket: NoBucket, PreDraw: pre, Draws: [2]int{-1, -1}}
+		draws0 := s.strategyOrUniform(k0, len(s.cfg.DrawOptions))
+		cs0 := &out[int(classes[0])]
+		weights[0][classes[0]] += p
+		for i, q := range draws0 {
+			cs0.Draws[i] += p * q
+			k1 := InfoSet{Player: 1, Class: classes[1], Bucket: NoBucket, PreDraw: pre, Draws: [2]int{i, -1}}
+			cs1 := &out[int(NumClasses)+int(classes[1])]
+			weights[1][classes[1]] += p * q
+			for j, r := range s.strategyOrUniform(k1, len(s.cfg.DrawOptions)) {
+				cs1.Draws[j] += p * q * r
+			}
+		}
+		return
+	}
+	if len(pre) > 0 && pre[len(pre)-1] == Fold {
+		return
+	}
+	actions, actor := bettingActions(pre)
+	k := InfoSet{Player: actor, Class: classes[actor], Bucket: NoBucket, PreDraw: pre, Draws: [2]int{-1, -1}}
+	for i, q := range s.strategyOrUniform(k, len(actions)) {
+		if q > 0 {
+			s.accumulateDraws(out, weights, classes, pre+string(actions[i]), p*q)
+		}
+	}
+}
+
+func (s *Solution) strategyOrUniform(k InfoSet, n int) []float64 {
+	if st, ok := s.strategies[k]; ok {
+		return st
+	}
+	st := make([]float64, n)
+	for i := range st {
+		st[i] = 1 / float64(n)
+	}
+	return st
+}
+
+// prob returns the probability of action i at k, or 0 if k was never reached
+func (s *Solution) prob(k InfoSet, i int) float64 {
+	if st, ok := s.strategies[k]; ok {
+		return st[i]
+	}
+	return 0
+}
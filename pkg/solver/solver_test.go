@@ -0,0 +1,126 @@
+package solver
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/handrank/variants/deucelowsingle"
+)
+
+func TestClassify(t *testing.T) {
+	ht := deucelowsingle.NewHashTable()
+	tests := []struct {
+		cards []card.Card
+		want  Class
+	}{
+		{[]card.Card{
+			card.NewCard(card.Spades, card.Seven), card.NewCard(card.Hearts, card.Five), card.NewCard(card.Diamonds, card.Four),
+			card.NewCard(card.Clubs, card.Three), card.NewCard(card.Spades, card.Two),
+		}, Pat7},
+		{[]card.Card{
+			card.NewCard(card.Spades, card.Nine), card.NewCard(card.Hearts, card.Eight), card.NewCard(card.Diamonds, card.Four),
+			card.NewCard(card.Clubs, card.Three), card.NewCard(card.Spades, card.Two),
+		}, Pat9},
+		{[]card.Card{
+			card.NewCard(card.Spades, card.Seven), card.NewCard(card.Hearts, card.Five), card.NewCard(card.Diamonds, card.Four),
+			card.NewCard(card.Clubs, card.Two), card.NewCard(card.Spades, card.King),
+		}, OneTo7},
+		{[]card.Card{
+			card.NewCard(card.Spades, card.Eight), card.NewCard(card.Hearts, card.Eight), card.NewCard(card.Diamonds, card.Six),
+			card.NewCard(card.Clubs, card.Two), card.NewCard(card.Spades, card.Three),
+		}, OneTo8},
+		{[]card.Card{
+			card.NewCard(card.Spades, card.Seven), card.NewCard(card.Hearts, card.Two), card.NewCard(card.Diamonds, card.Three),
+			card.NewCard(card.Clubs, card.King), card.NewCard(card.Spades, card.King),
+		}, TwoTo7},
+		// A-2-3-4-5 is ace high in 2-7 and draws one to a five-low base
+		{[]card.Card{
+			card.NewCard(card.Spades, card.Ace), card.NewCard(card.Hearts, card.Two), card.NewCard(card.Diamonds, card.Three),
+			card.NewCard(card.Clubs, card.Four), card.NewCard(card.Spades, card.Five),
+		}, OneTo7},
+		{[]card.Card{
+			card.NewCard(card.Spades, card.Ace), card.NewCard(card.Hearts, card.King), card.NewCard(card.Diamonds, card.Queen),
+			card.NewCard(card.Clubs, card.Four), card.NewCard(card.Spades, card.Two),
+		}, Rough},
+	}
+	for _, tt := range tests {
+		if got := Classify(ht, tt.cards); got != tt.want {
+			t.Errorf("Expected %v to be %v, got %v", tt.cards, tt.want, got)
+		}
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	ht := deucelowsingle.NewHashTable()
+	rng := rand.New(rand.NewSource(40))
+	cards := make([]card.Card, 52)
+	for i := range cards {
+		cards[i] = card.Card(i)
+	}
+	for trial := 0; trial < 20000; trial++ {
+		rng.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+		a, b := ht.Value(cards[:5]), ht.Value(cards[5:10])
+		ba, bb := BucketOf(a), BucketOf(b)
+		if ba < 0 || ba >= NumBuckets {
+			t.Fatalf("Bucket %d out of range for %v", ba, cards[:5])
+		}
+		if a < b && ba > bb {
+			t.Fatalf("Expected %v to bucket no worse than %v, got %d and %d", cards[:5], cards[5:10], ba, bb)
+		}
+	}
+}
+
+func TestSolve(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Samples, cfg.Trials, cfg.Iterations = 3000, 10, 60000
+	s, err := NewSolver(deucelowsingle.NewHashTable(), cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var total float64
+	for _, f := range s.Abstraction().ClassFreq {
+		total += f
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("Expected class frequencies to sum to 1, got %f", total)
+	}
+
+	sol := s.Solve()
+	if sol.InfoSets() == 0 {
+		t.Fatal("Expected information sets to be visited")
+	}
+	for _, cs := range sol.ClassStrategies() {
+		var sum float64
+		for _, f := range cs.Draws {
+			sum += f
+		}
+		if math.Abs(sum-1) > 1e-6 {
+			t.Errorf("Expected %v draw frequencies for player %d to sum to 1, got %f", cs.Class, cs.Player, sum)
+		}
+		switch cs.Class {
+		case Pat7:
+			if cs.Draws[0] < 0.8 {
+				t.Errorf("Expected player %d to stand pat with a pat 7 almost always, got %.2f", cs.Player, cs.Draws[0])
+			}
+			if cs.Bet < 0.5 {
+				t.Errorf("Expected player %d to bet a pat 7 most of the time, got %.2f", cs.Player, cs.Bet)
+			}
+		case OneTo7:
+			if cs.Draws[1] < 0.5 {
+				t.Errorf("Expected player %d to draw one to a 7 mostly, got %.2f", cs.Player, cs.Draws[1])
+			}
+		}
+	}
+
+	k := InfoSet{Player: 0, Class: Pat7, Bucket: NoBucket, Draws: [2]int{-1, -1}}
+	if got := sol.Actions(k); len(got) != 2 || got[0] != "check" || got[1] != "bet" {
+		t.Errorf("Expected check and bet, got %v", got)
+	}
+	k.PreDraw = "cc"
+	if got := sol.Actions(k); len(got) != len(cfg.DrawOptions) || got[0] != "pat" {
+		t.Errorf("Expected draw options, got %v", got)
+	}
+}
@@ -0,0 +1,150 @@
+package suitiso
+
+import (
+	"math/bits"
+	"sort"
+
+	"github.com/dgunzy/card/pkg/card"
+)
+
+// EachDraw calls fn once for each class of n-card draws from live that are
+// suit permutations of each other under the relabellings that leave fixed
+// and live unchanged, such as the kept and dead cards of a draw. weight is
+// the number of draws in the class, so weights sum to the number of n-card
+// subsets of live. drawn is reused between calls.
+//
+// The results match a full enumeration only for evaluators that treat the
+// suits alike. Live jokers are enumerated in full.
+func EachDraw(live []card.Card, n int, fixed [][]card.Card, fn func(drawn []card.Card, weight uint64)) {
+	c := Canonicalize(append(append([][]card.Card(nil), fixed...), live)...)
+	var inverse [NumSuits]int
+	for s, canonical := range c.Perm {
+		inverse[canonical] = s
+	}
+
+	// Suits in canonical order, with the class each belongs to
+	var order, class [NumSuits]int
+	for i, cl := range c.Classes {
+		for _, canonical := range cl {
+			order[canonical] = inverse[canonical]
+			class[canonical] = i
+		}
+	}
+
+	var liveMask [NumSuits]uint16
+	var jokers []card.Card
+	for _, x := range live {
+		if suited(x) {
+			liveMask[suitIndex(x)] |= 1 << x.Rank()
+		} else {
+			jokers = append(jokers, x)
+		}
+	}
+	// capacity[p] is the number of live cards in suits from position p on
+	var capacity [NumSuits + 1]int
+	for p := NumSuits - 1; p >= 0; p-- {
+		capacity[p] = capacity[p+1] + bits.OnesCount16(liveMask[order[p]])
+	}
+
+	// subsets[p][k] lists the k-card rank masks of the live cards of the
+	// suit at position p
+	var subsets [NumSuits][][]uint16
+	for p, s := range order {
+		subsets[p] = rankSubsets(liveMask[s], n)
+	}
+
+	drawn := make([]card.Card, 0, n)
+	var keys [NumSuits]uint32
+	var suitedDraws func(p, left int, weight uint64)
+	suitedDraws = func(p, left int, weight uint64) {
+		if left > capacity[p] {
+			return
+		}
+		if p == NumSuits {
+			fn(drawn, weight*classWeight(keys[:], class[:]))
+			return
+		}
+		s := order[p]
+		for k := 0; k <= left && k < len(subsets[p]); k++ {
+			for _, m := range subsets[p][k] {
+				key := uint32(k)<<16 | uint32(m)
+				// Within a class of interchangeable suits, only draws whose
+				// suits are in non-increasing order are visited
+				if p > 0 && class[p] == class[p-1] && key > keys[p-1] {
+					break
+				}
+				keys[p] = key
+				mark := len(drawn)
+				for r := 0; r < 16; r++ {
+					if m&(1<<r) != 0 {
+						drawn = append(drawn, card.NewCard(suits[s], card.Rank(r)))
+					}
+				}
+				suitedDraws(p+1, left-k, weight)
+				drawn = drawn[:mark]
+			}
+		}
+	}
+
+	// Jokers have no suit, so each choice of them is enumerated directly
+	var jokerDraws func(start, left int)
+	jokerDraws = func(start, left int) {
+		suitedDraws(0, left, 1)
+		for i := start; i < len(jokers) && left > 0; i++ {
+			drawn = append(drawn, jokers[i])
+			jokerDraws(i+1, left-1)
+			drawn = drawn[:len(drawn)-1]
+		}
+	}
+	jokerDraws(0, n)
+}
+
+// rankSubsets returns the subsets of live with up to n ranks, grouped by
+// size and each group in increasing order
+func rankSubsets(live uint16, n int) [][]uint16 {
+	var ranks []uint16
+	for r := 0; r < 16; r++ {
+		if live&(1<<r) != 0 {
+			ranks = append(ranks, 1<<r)
+		}
+	}
+	out := make([][]uint16, min(n, len(ranks))+1)
+	var choose func(start int, mask uint16, size int)
+	choose = func(start int, mask uint16, size int) {
+		out[size] = append(out[size], mask)
+		if size == len(out)-1 {
+			return
+		}
+		for i := start; i < len(ranks); i++ {
+			choose(i+1, mask|ranks[i], size+1)
+		}
+	}
+	choose(0, 0, 0)
+	for _, group := range out {
+		sort.Slice(group, func(i, j int) bool { return group[i] < group[j] })
+	}
+	return out
+}
+
+// classWeight returns the number of distinct orderings of the per-suit draw
+// keys within each class of interchangeable suits
+func classWeight(keys []uint32, class []int) uint64 {
+	w := uint64(1)
+	for start := 0; start < len(keys); {
+		end := start
+		for end < len(keys) && class[end] == class[start] {
+			end++
+		}
+		w *= uint64(factorial(end - start))
+		for i := start; i < end; {
+			j := i
+			for j < end && keys[j] == keys[i] {
+				j++
+			}
+			w /= uint64(factorial(j - i))
+			i = j
+		}
+		start = end
+	}
+	return w
+}
@@ -0,0 +1,147 @@
+// Package suitiso reduces card sets by suit isomorphism. Poker evaluators
+// treat the four suits alike, so sets of cards that differ only by a
+// relabelling of suits give the same results; canonicalizing them lets
+// tables, caches and enumerations do the work once for each class.
+package suitiso
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+)
+
+// NumSuits is the number of suits a relabelling permutes
+const NumSuits = 4
+
+// suits lists the suits by their index in a relabelling
+var suits = [NumSuits]card.Suit{card.Spades, card.Hearts, card.Diamonds, card.Clubs}
+
+// suitIndex returns the index in suits of c's suit
+func suitIndex(c card.Card) int {
+	s := c.Suit()
+	for i, x := range suits {
+		if x == s {
+			return i
+		}
+	}
+	return 0
+}
+
+// Canonical is a set of card groups, such as kept, dead and board cards,
+// relabelled to a canonical choice of suits. Groups that are suit
+// permutations of each other have the same canonical form.
+type Canonical struct {
+	// Groups holds the input groups with their suits relabelled, each in
+	// the order given
+	Groups [][]card.Card
+	// Perm maps the index of each input suit to its canonical index
+	Perm [NumSuits]int
+	// Classes partitions the canonical suit indexes into suits that are
+	// interchangeable: every group holds the same ranks in each of them
+	Classes [][]int
+}
+
+// Canonicalize relabels the suits of groups so that suits holding more and
+// higher cards, compared group by group, come first. Jokers and other cards
+// outside the standard deck are left as they are.
+func Canonicalize(groups ...[]card.Card) Canonical {
+	sigs := signatures(groups)
+	order := []int{0, 1, 2, 3}
+	sort.SliceStable(order, func(i, j int) bool { return sigLess(sigs[order[j]], sigs[order[i]]) })
+
+	c := Canonical{Groups: make([][]card.Card, len(groups))}
+	for canonical, s := range order {
+		c.Perm[s] = canonical
+		if canonical > 0 && !sigLess(sigs[s], sigs[order[canonical-1]]) {
+			last := c.Classes[len(c.Classes)-1]
+			c.Classes[len(c.Classes)-1] = append(last, canonical)
+		} else {
+			c.Classes = append(c.Classes, []int{canonical})
+		}
+	}
+	for i, g := range groups {
+		c.Groups[i] = c.Apply(g)
+	}
+	return c
+}
+
+// signatures returns each suit's rank mask in every group
+func signatures(groups [][]card.Card) [NumSuits][]uint16 {
+	var sigs [NumSuits][]uint16
+	for s := range sigs {
+		sigs[s] = make([]uint16, len(groups))
+	}
+	for i, g := range groups {
+		for _, c := range g {
+			if suited(c) {
+				sigs[suitIndex(c)][i] |= 1 << c.Rank()
+			}
+		}
+	}
+	return sigs
+}
+
+// sigLess orders signatures group by group
+func sigLess(a, b []uint16) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func suited(c card.Card) bool {
+	return int(c) < deck.StandardSize
+}
+
+// Apply relabels the suits of cards with the canonical permutation
+func (c Canonical) Apply(cards []card.Card) []card.Card {
+	out := make([]card.Card, len(cards))
+	for i, x := range cards {
+		if !suited(x) {
+			out[i] = x
+			continue
+		}
+		out[i] = card.NewCard(suits[c.Perm[suitIndex(x)]], x.Rank())
+	}
+	return out
+}
+
+// Multiplicity returns how many distinct suit relabellings of the groups
+// there are, each with this canonical form
+func (c Canonical) Multiplicity() int {
+	m := factorial(NumSuits)
+	for _, class := range c.Classes {
+		m /= factorial(len(class))
+	}
+	return m
+}
+
+// Key returns a compact string identifying the canonical form, equal for
+// groups that are suit permutations of each other. Cards are compared as
+// sets within each group.
+func (c Canonical) Key() string {
+	var b strings.Builder
+	for i, g := range c.Groups {
+		if i > 0 {
+			b.WriteByte(0xff)
+		}
+		sorted := append([]card.Card(nil), g...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		for _, x := range sorted {
+			b.WriteByte(byte(x))
+		}
+	}
+	return b.String()
+}
+
+func factorial(n int) int {
+	f := 1
+	for i := 2; i <= n; i++ {
+		f *= i
+	}
+	return f
+}
@@ -0,0 +1,77 @@
+package suitiso
+
+import (
+	"testing"
+
+	"github.com/dgunzy/card/pkg/card"
+	"github.com/dgunzy/hand-eval/pkg/deck"
+)
+
+func TestCanonicalize(t *testing.T) {
+	kept := []card.Card{card.NewCard(card.Hearts, card.Seven), card.NewCard(card.Hearts, card.Five), card.NewCard(card.Clubs, card.Two)}
+	dead := []card.Card{card.NewCard(card.Diamonds, card.King)}
+	// The same sets with hearts and spades, and clubs and diamonds, swapped
+	swapped := []card.Card{card.NewCard(card.Spades, card.Seven), card.NewCard(card.Spades, card.Five), card.NewCard(card.Diamonds, card.Two)}
+	swappedDead := []card.Card{card.NewCard(card.Clubs, card.King)}
+
+	a, b := Canonicalize(kept, dead), Canonicalize(swapped, swappedDead)
+	if a.Key() != b.Key() {
+		t.Errorf("Expected suit permutations to share a key, got %q and %q", a.Key(), b.Key())
+	}
+	if other := Canonicalize(kept, swappedDead); other.Key() == a.Key() {
+		t.Errorf("Expected different dead cards to change the key %q", a.Key())
+	}
+	// Hearts, clubs and diamonds are each distinct; spades is alone too
+	if got := a.Multiplicity(); got != 24 {
+		t.Errorf("Expected 24 relabellings, got %d", got)
+	}
+	// Two suited cards leave the three other suits interchangeable
+	if got := Canonicalize(kept[:2]).Multiplicity(); got != 4 {
+		t.Errorf("Expected 4 relabellings of a suited pair of cards, got %d", got)
+	}
+	if got := Canonicalize(nil).Multiplicity(); got != 1 {
+		t.Errorf("Expected 1 relabelling of no cards, got %d", got)
+	}
+}
+
+func TestEachDraw(t *testing.T) {
+	tests := []struct {
+		name       string
+		used       []card.Card
+		d          deck.Deck
+		n          int
+		subsets    uint64
+		maxClasses int
+	}{
+		{"full deck", nil, deck.Standard(), 3, 22100, 22100 / 6},
+		{"suited kept", []card.Card{card.NewCard(card.Hearts, card.Seven), card.NewCard(card.Hearts, card.Five)}, deck.Standard(), 3, 19600, 19600 / 2},
+		{"jokers", nil, deck.Standard().WithJokers(2), 2, 1431, 1431},
+	}
+	for _, tt := range tests {
+		var live []card.Card
+		for _, c := range tt.d.Without(tt.used...).Cards() {
+			live = append(live, c)
+		}
+		var total uint64
+		classes := 0
+		seen := make(map[string]bool)
+		EachDraw(live, tt.n, [][]card.Card{tt.used}, func(drawn []card.Card, weight uint64) {
+			if len(drawn) != tt.n {
+				t.Fatalf("%s: expected %d cards, got %v", tt.name, tt.n, drawn)
+			}
+			key := Canonicalize(tt.used, drawn).Key()
+			if seen[key] {
+				t.Fatalf("%s: draw class %s visited twice", tt.name, drawn)
+			}
+			seen[key] = true
+			total += weight
+			classes++
+		})
+		if total != tt.subsets {
+			t.Errorf("%s: expected weights to sum to %d, got %d", tt.name, tt.subsets, total)
+		}
+		if classes > tt.maxClasses {
+			t.Errorf("%s: expected at most %d classes, got %d", tt.name, tt.maxClasses, classes)
+		}
+	}
+}